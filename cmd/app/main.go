@@ -34,25 +34,30 @@ package main
 import "C"
 
 import (
+	"context"
 	"os"
 
 	"github.com/Laky-64/gologging"
 
 	"main/internal/config"
 	"main/internal/core"
+	state "main/internal/core/models"
 	"main/internal/database"
 	"main/internal/modules"
+	"main/internal/platforms"
+	"main/internal/server"
 )
 
 func main() {
+	config.Load()
 	initLogger()
 	defer config.CloseLogging()
 
 	checkFFmpegAndFFprobe()
 	refreshCacheAndDownloads()
 
-	gologging.Debug("🔹 Initializing MongoDB...")
-	dbCleanup := database.Init(config.MongoURI)
+	gologging.Debug("🔹 Initializing database...")
+	dbCleanup := database.Init(config.MongoURI, config.DatabaseFile)
 	defer dbCleanup()
 	gologging.Info("✅ Database connected successfully")
 	gologging.Debug("🔹 Initializing clients...")
@@ -68,12 +73,28 @@ func main() {
 
 	core.AssistantIndexFunc = database.GetAssistantIndex
 	core.GetChatLanguage = database.GetChatLanguage
+	core.SaveRoomSnapshot = database.SaveRoomSnapshot
+	core.DeleteRoomSnapshot = database.DeleteRoomSnapshot
+	core.RecordHistory = database.AddHistory
+	core.SettingsChatID = database.SettingsChatID
+	core.GetFairQueue = database.GetFairQueue
+	core.GetCrossfade = database.GetCrossfade
+	core.GetVolume = database.GetVolume
+	core.TrackGain = platforms.TrackGain
+	platforms.OnTrackGain = core.ApplyTrackGain
+	core.DownloadTrack = func(ctx context.Context, t *state.Track) (string, error) {
+		return platforms.Download(ctx, t, nil)
+	}
 
 	if err := database.RebalanceAssistantIndexes(core.Assistants.Count()); err != nil {
 		gologging.Fatal("Failed to rebalance Assistants: " + err.Error())
 	}
 
 	modules.Init(core.Bot, core.Assistants)
+
+	if config.HTTPAddr != "" {
+		server.Start(config.HTTPAddr)
+	}
 	core.Bot.Idle()
 }
 
@@ -40,7 +40,6 @@ var (
 	ApiHash        = getString("API_HASH")
 	Token          = getString("TOKEN")
 	LoggerID       = getInt64("LOGGER_ID")
-	MongoURI       = getString("MONGO_DB_URI")
 	StringSessions = getStringSlice("STRING_SESSIONS")
 	SessionType    = getString(
 		"SESSION_TYPE",
@@ -50,6 +49,9 @@ var (
 	// Optional Vars
 	OwnerID = getInt64("OWNER_ID")
 
+	MongoURI     = getString("MONGO_DB_URI")
+	DatabaseFile = getString("DATABASE_FILE", "database.json") // used when MONGO_DB_URI is empty
+
 	SpotifyClientID = getString(
 		"SPOTIFY_CLIENT_ID",
 		"19609edb1b9f4ed7be0c8c1342039362",
@@ -75,6 +77,23 @@ var (
 	CookiesLink    = getString("COOKIES_LINK")
 	SetCmds        = getBool("SET_CMDS", false)
 	MaxAuthUsers   = int(getInt64("MAX_AUTH_USERS", 25))
+	ResumeMode     = strings.ToLower(getString("RESUME_MODE", "ask")) // ask, auto, off
+
+	PrefetchTracks  = int(getInt64("PREFETCH_TRACKS", 2))  // queued tracks downloaded ahead per chat
+	PrefetchWorkers = int(getInt64("PREFETCH_WORKERS", 2)) // prefetch downloads running at once
+
+	NormalizeLoudness = getBool("NORMALIZE_LOUDNESS", false)
+	LoudnessTarget    = getFloat64("LOUDNESS_TARGET", -14) // LUFS
+
+	HTTPAddr = getString("HTTP_ADDR") // e.g. :8080, empty disables the HTTP server
+
+	LyricsDir = getString("LYRICS_DIR", "lyrics") // .lrc and .txt files checked before online providers
+	LrclibURL = getString("LRCLIB_URL", "https://lrclib.net")
+
+	RadioFile       = getString("RADIO_FILE", "radio.yml") // curated stations searched before the online directory
+	RadioBrowserURL = getString("RADIO_BROWSER_URL", "https://de1.api.radio-browser.info")
+
+	Timezone = getString("TIMEZONE") // IANA name used by /schedule, empty means the server's zone
 
 	StartImage = getString(
 		"START_IMG_URL",
@@ -86,11 +105,14 @@ var (
 	)
 
 	LogFileName = "logs.txt"
-	LogWriter   io.Writer
+	LogWriter   = io.Writer(os.Stderr) // a log file is added by Load
 	logFile     *os.File
 )
 
-func init() {
+// Load opens the log file and checks the required variables, exiting if
+// one is missing. main calls it before anything else; until then logs go
+// to stderr only.
+func Load() {
 	initLogging()
 	validateRequired()
 	validateToken()
@@ -125,10 +147,6 @@ func validateRequired() {
 	if LoggerID == 0 {
 		logger.Fatal("LOGGER_ID is required but missing!")
 	}
-
-	if MongoURI == "" {
-		logger.Fatal("MONGO_DB_URI is required but missing!")
-	}
 }
 
 func validateToken() {
@@ -191,6 +209,23 @@ func getBool(key string, def ...bool) bool {
 	return defaultValue
 }
 
+func getFloat64(key string, def ...float64) float64 {
+	defaultValue := 0.0
+	if len(def) > 0 {
+		defaultValue = def[0]
+	}
+
+	if val, ok := getEnvAny(variants(key)...); ok {
+		num, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.FatalF("Invalid float for %s: %v", key, err)
+			return defaultValue
+		}
+		return num
+	}
+	return defaultValue
+}
+
 func getInt64(key string, def ...int64) int64 {
 	defaultValue := int64(0)
 	if len(def) > 0 {
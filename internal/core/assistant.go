@@ -26,15 +26,13 @@ import (
 
 	"github.com/Laky-64/gologging"
 	"github.com/amarnathcjd/gogram/telegram"
-
-	"main/ubot"
 )
 
 type Assistant struct {
 	Index  int
 	Client *telegram.Client
 	User   *telegram.UserObj
-	Ntg    *ubot.Context
+	Ntg    *NtgContext
 }
 
 type AssistantManager struct {
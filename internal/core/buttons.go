@@ -22,6 +22,8 @@ package core
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Laky-64/gologging"
 	tg "github.com/amarnathcjd/gogram/telegram"
@@ -85,6 +87,12 @@ func GetPlayMarkup(chatID int64, r *RoomState, queued bool) tg.ReplyMarkup {
 	) + " " + progress + " " + formatDuration(
 		duration,
 	)
+	live := track != nil && track.IsLive
+	if live {
+		progress = F(chatID, "LIVE_BTN", locales.Arg{
+			"time": formatDuration(r.Position()),
+		})
+	}
 
 	if !queued {
 		btn.AddRow(
@@ -98,19 +106,79 @@ func GetPlayMarkup(chatID int64, r *RoomState, queued bool) tg.ReplyMarkup {
 		tg.Button.Data("▢", prefix+"stop"),
 	)
 
+	// live streams cannot be seeked
+	if live {
+		btn.AddRow(
+			tg.Button.Data("🔉", prefix+"voldown"),
+			tg.Button.Data("⟳", "room:replay"),
+			tg.Button.Data("🔊", prefix+"volup"),
+		)
+	} else {
+		btn.AddRow(
+			tg.Button.Data("↩ 15s", "room:seekback_15"),
+			tg.Button.Data("🔉", prefix+"voldown"),
+			tg.Button.Data("⟳", "room:replay"),
+			tg.Button.Data("🔊", prefix+"volup"),
+			tg.Button.Data("15s ↪", "room:seek_15"),
+		)
+	}
+
+	// a pending sleep timer or stop-after count, tap to cancel
+	if mins := r.SleepMinutes(); mins > 0 {
+		btn.AddRow(tg.Button.Data(F(chatID, "SLEEP_TIMER_BTN", locales.Arg{
+			"minutes": mins,
+		}), prefix+"sleep_off"))
+	} else if n := r.StopAfter(); n > 0 {
+		btn.AddRow(tg.Button.Data(F(chatID, "STOP_AFTER_BTN", locales.Arg{
+			"count": n,
+		}), prefix+"sleep_off"))
+	}
+
 	btn.AddRow(
-		tg.Button.Data("↩ 15s", "room:seekback_15"),
-		tg.Button.Data("⟳", "room:replay"),
-		tg.Button.Data("15s ↪", "room:seek_15"),
+		tg.Button.Data(F(chatID, "VOTESKIP_BTN"), prefix+"voteskip"),
+		tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"),
 	)
 
+	return btn.Build()
+}
+
+// GetEffectsMarkup lists every effect preset as a toggle button, marking
+// the enabled ones.
+func GetEffectsMarkup(chatID int64, r *RoomState) tg.ReplyMarkup {
+	btn := tg.NewKeyboard()
+	prefix := "room:"
+	if r.IsCPlay() {
+		prefix = "croom:"
+	}
+	enabled := r.Effects()
+
+	buttons := make([]tg.KeyboardButton, len(Effects))
+	for i, e := range Effects {
+		label := F(chatID, "FX_"+strings.ToUpper(e)+"_BTN")
+		if slices.Contains(enabled, e) {
+			label = "✅ " + label
+		}
+		buttons[i] = tg.Button.Data(label, prefix+"fx_"+e)
+	}
+	btn.NewColumn(3, buttons...)
+
 	btn.AddRow(
+		tg.Button.Data(F(chatID, "FX_OFF_BTN"), prefix+"fx_off"),
 		tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"),
 	)
-
 	return btn.Build()
 }
 
+func GetRestoreMarkup(chatID, roomID int64) tg.ReplyMarkup {
+	id := utils.IntToStr(roomID)
+	return tg.NewKeyboard().
+		AddRow(
+			tg.Button.Data(F(chatID, "RESTORE_BTN"), "restore:yes:"+id),
+			tg.Button.Data(F(chatID, "DISMISS_BTN"), "restore:no:"+id),
+		).
+		Build()
+}
+
 func GetGroupHelpKeyboard(chatID int64) *tg.ReplyInlineMarkup {
 	return tg.NewKeyboard().
 		AddRow(
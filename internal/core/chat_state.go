@@ -99,8 +99,17 @@ func DeleteChatState(chatID int64) {
 
 func (s *ChatState) SetAssistantPresent(v bool) {
 	s.mu.Lock()
+	changed := s.isPresent != nil && *s.isPresent != v
 	s.isPresent = &v
 	s.mu.Unlock()
+
+	if changed && s.Assistant != nil {
+		publish(Event{
+			Type:      utils.IfElse(v, EventAssistantJoined, EventAssistantLeft),
+			ChatID:    s.ChatID,
+			Assistant: s.Assistant.User.ID,
+		})
+	}
 }
 
 func (s *ChatState) SetAssistantBanned(v bool) {
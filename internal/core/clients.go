@@ -29,8 +29,6 @@ import (
 
 	"github.com/Laky-64/gologging"
 	"github.com/amarnathcjd/gogram/telegram"
-
-	"main/ubot"
 )
 
 var (
@@ -65,7 +63,7 @@ func Init(
 
 		client := initAssistantClient(apiID, apiHash, sess, sessionType, i)
 		user := getSelfOrFatal(client, fmt.Sprintf("assistant[%d]", i))
-		ctx := ubot.NewContext(client)
+		ctx := newNtgContext(client)
 
 		client.SetCommandPrefixes(".")
 
@@ -0,0 +1,125 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"sync"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+// EventType names something that happened in a room.
+type EventType string
+
+const (
+	EventTrackStarted    EventType = "track_started"
+	EventTrackEnded      EventType = "track_ended" // Reason tells how
+	EventQueued          EventType = "queued"
+	EventSkipped         EventType = "skipped" // follows EventTrackEnded
+	EventPaused          EventType = "paused"
+	EventResumed         EventType = "resumed"
+	EventSeeked          EventType = "seeked"
+	EventSpeedChanged    EventType = "speed_changed"
+	EventRoomDestroyed   EventType = "room_destroyed"
+	EventAssistantJoined EventType = "assistant_joined"
+	EventAssistantLeft   EventType = "assistant_left"
+)
+
+// Event is published whenever a room changes. Fields that don't apply to
+// Type are left empty.
+type Event struct {
+	Type      EventType
+	ChatID    int64
+	Time      int64 // unix seconds
+	Track     *state.Track
+	Position  int // playback position in seconds
+	Index     int // 1-based queue position, EventQueued only
+	Speed     float64
+	Paused    bool
+	Reason    state.EndReason
+	Assistant int64 // assistant user ID, assistant events only
+}
+
+type eventSub struct {
+	chatID int64
+	ch     chan Event
+}
+
+var (
+	eventSubs   = make(map[*eventSub]struct{})
+	eventSubsMu sync.RWMutex
+)
+
+// Subscribe returns a channel that receives the events of chatID, or of
+// every chat when chatID is 0, and a function that ends the subscription
+// and closes the channel. Events are dropped for subscribers whose buffer
+// is full so a slow reader never blocks playback.
+func Subscribe(chatID int64, buffer int) (<-chan Event, func()) {
+	sub := &eventSub{chatID: chatID, ch: make(chan Event, max(buffer, 1))}
+
+	eventSubsMu.Lock()
+	eventSubs[sub] = struct{}{}
+	eventSubsMu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			eventSubsMu.Lock()
+			delete(eventSubs, sub)
+			close(sub.ch)
+			eventSubsMu.Unlock()
+		})
+	}
+}
+
+func publish(e Event) {
+	e.Time = time.Now().Unix()
+
+	eventSubsMu.RLock()
+	defer eventSubsMu.RUnlock()
+
+	for sub := range eventSubs {
+		if sub.chatID != 0 && sub.chatID != e.ChatID {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+		}
+	}
+}
+
+// emit publishes an event of typ carrying the room's current playback
+// state. The caller must hold r's lock.
+func (r *RoomState) emit(typ EventType) {
+	publish(r.event(typ))
+}
+
+func (r *RoomState) event(typ EventType) Event {
+	return Event{
+		Type:     typ,
+		ChatID:   r.chatID,
+		Track:    r.track,
+		Position: r.position,
+		Speed:    r.speed,
+		Paused:   r.paused,
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"sync"
+
+	state "main/internal/core/models"
+)
+
+// FakeCall is a single call recorded by FakePlayer, together with the
+// room state the call was made with. The room state is only filled in for
+// play and stop.
+type FakeCall struct {
+	Method   string // play, pause, resume, stop, mute, unmute
+	ChatID   int64
+	Track    *state.Track
+	Path     string
+	Position int
+	Speed    float64
+}
+
+// FakePlayer is an in-memory Player that needs neither ntgcalls nor a
+// Telegram connection. It records every call and can simulate the end
+// of a stream, so room behaviour can be exercised without a voice chat.
+//
+//	fake := core.NewFakePlayer()
+//	core.NewPlayer = func(int64, *core.Assistant) core.Player { return fake }
+type FakePlayer struct {
+	mu     sync.Mutex
+	calls  []FakeCall
+	paused map[int64]bool
+	muted  map[int64]bool
+
+	// Err, when set, is returned by every following call.
+	Err error
+	// OnStreamEnd is invoked by EndStream, usually with the same handler
+	// that is registered on the real assistant.
+	OnStreamEnd func(chatID int64)
+}
+
+func NewFakePlayer() *FakePlayer {
+	return &FakePlayer{
+		paused: make(map[int64]bool),
+		muted:  make(map[int64]bool),
+	}
+}
+
+// record appends a call. Only Play and Stop are made with the room
+// locked, the other calls record the chat ID alone so that reading the
+// room never races with its owner.
+func (p *FakePlayer) record(method string, r *RoomState) error {
+	call := FakeCall{Method: method, ChatID: r.chatID}
+	if method == "play" || method == "stop" {
+		call.Track = r.track
+		call.Path = r.fpath
+		call.Position = r.position
+		call.Speed = r.speed
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, call)
+	return p.Err
+}
+
+// toggle mimics ntgcalls: it reports whether the flag actually changed.
+func (p *FakePlayer) toggle(m map[int64]bool, chatID int64, v bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if m[chatID] == v {
+		return false
+	}
+	m[chatID] = v
+	return true
+}
+
+func (p *FakePlayer) Play(r *RoomState) error {
+	if err := p.record("play", r); err != nil {
+		return err
+	}
+	p.toggle(p.paused, r.chatID, false)
+	return nil
+}
+
+func (p *FakePlayer) Pause(r *RoomState) (bool, error) {
+	if err := p.record("pause", r); err != nil {
+		return false, err
+	}
+	return p.toggle(p.paused, r.chatID, true), nil
+}
+
+func (p *FakePlayer) Resume(r *RoomState) (bool, error) {
+	if err := p.record("resume", r); err != nil {
+		return false, err
+	}
+	return p.toggle(p.paused, r.chatID, false), nil
+}
+
+func (p *FakePlayer) Stop(r *RoomState) error {
+	if err := p.record("stop", r); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	delete(p.paused, r.chatID)
+	delete(p.muted, r.chatID)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *FakePlayer) Mute(r *RoomState) (bool, error) {
+	if err := p.record("mute", r); err != nil {
+		return false, err
+	}
+	return p.toggle(p.muted, r.chatID, true), nil
+}
+
+func (p *FakePlayer) Unmute(r *RoomState) (bool, error) {
+	if err := p.record("unmute", r); err != nil {
+		return false, err
+	}
+	return p.toggle(p.muted, r.chatID, false), nil
+}
+
+// EndStream simulates ntgcalls reporting the end of the current stream.
+// It must not be called while holding the room's lock.
+func (p *FakePlayer) EndStream(chatID int64) {
+	if p.OnStreamEnd != nil {
+		p.OnStreamEnd(chatID)
+	}
+}
+
+// Calls returns a copy of every recorded call, oldest first.
+func (p *FakePlayer) Calls() []FakeCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	calls := make([]FakeCall, len(p.calls))
+	copy(calls, p.calls)
+	return calls
+}
+
+// LastCall returns the most recent call, if any.
+func (p *FakePlayer) LastCall() (FakeCall, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.calls) == 0 {
+		return FakeCall{}, false
+	}
+	return p.calls[len(p.calls)-1], true
+}
+
+// Reset forgets all recorded calls.
+func (p *FakePlayer) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = nil
+}
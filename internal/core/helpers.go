@@ -22,11 +22,35 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	state "main/internal/core/models"
 	"main/internal/utils"
 )
 
+var TrackGain func(trackID string) (float64, bool) // overwritten from main.go
+
+// gainFilter returns the filter that brings t to the loudness target, ""
+// when t wasn't analysed.
+func gainFilter(t *state.Track) string {
+	if TrackGain == nil || t == nil {
+		return ""
+	}
+	gain, ok := TrackGain(t.ID)
+	if !ok || gain == 0 {
+		return ""
+	}
+	return fmt.Sprintf("volume=%.2fdB", gain)
+}
+
+// joinFilters chains the non-empty ffmpeg filters.
+func joinFilters(filters ...string) string {
+	return strings.Join(slices.DeleteFunc(filters, func(f string) bool {
+		return f == ""
+	}), ",")
+}
+
 func buildAudioFilter(speed float64) string {
 	if speed == 1.0 {
 		return ""
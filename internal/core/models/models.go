@@ -24,24 +24,60 @@ package state
 
 import (
 	"context"
+	"time"
 
 	"github.com/amarnathcjd/gogram/telegram"
 )
 
 type (
 	Track struct {
-		ID        string       // track unique id
-		Title     string       // title
-		Duration  int          // track duration in seconds
-		Artwork   string       // thumbnail url of the track
-		URL       string       // track url
-		Requester string       // html mention or @username who requested this track
-		Video     bool         // whether this track will be played as video
-		Source    PlatformName // unique PlatformName
-		IsLive    bool         // <-- ADD THIS FIELD: indicates if the track is a live stream
+		ID          string       // track unique id
+		Title       string       // title
+		Duration    int          // track duration in seconds
+		Artwork     string       // thumbnail url of the track
+		URL         string       // track url
+		Requester   string       // html mention or @username who requested this track
+		RequesterID int64        // telegram user id of the requester, 0 if unknown
+		Video       bool         // whether this track will be played as video
+		Source      PlatformName // unique PlatformName
+		IsLive      bool         // <-- ADD THIS FIELD: indicates if the track is a live stream
+		Channel     string       // uploader or channel name, empty if unknown
 	}
 	PlatformName string
 
+	// EndReason tells how a track stopped playing.
+	EndReason string
+
+	// HistoryEntry is a track that was played in a chat.
+	HistoryEntry struct {
+		ID          int64     `bson:"id"` // unix nano, unique within a chat
+		Track       *Track    `bson:"track"`
+		ChatID      int64     `bson:"chat_id"`
+		RequesterID int64     `bson:"requester_id"`
+		StartedAt   int64     `bson:"started_at"`
+		EndedAt     int64     `bson:"ended_at"`
+		Played      int       `bson:"played"` // seconds actually played
+		Reason      EndReason `bson:"reason"`
+	}
+
+	// RoomSnapshot is the persisted playback state of a room, used to
+	// resume playback after a restart.
+	RoomSnapshot struct {
+		ChatID    int64    `bson:"_id"`
+		Track     *Track   `bson:"track"`
+		Position  int      `bson:"position"`
+		Queue     []*Track `bson:"queue"`
+		Speed     float64  `bson:"speed"`
+		Loop      int      `bson:"loop"`
+		Shuffle   bool     `bson:"shuffle"`
+		CPlay     bool     `bson:"cplay"`
+		UpdatedAt int64    `bson:"updated_at"`
+
+		Effects []string  `bson:"effects,omitempty"`
+		EQ      []float64 `bson:"eq,omitempty"`
+		Volume  int       `bson:"volume,omitempty"`
+	}
+
 	Platform interface {
 		Name() PlatformName
 		IsValid(query string) bool
@@ -53,4 +89,60 @@ type (
 		) (string, error)
 		IsDownloadSupported(source PlatformName) bool
 	}
+
+	// Searcher is implemented by platforms that can list several results
+	// for a text query, it is what /search offers to pick from.
+	Searcher interface {
+		Search(query string, limit int, video bool) ([]*Track, error)
+	}
+
+	// Lyrics of a track. Synced holds the timed lines of LRC lyrics and is
+	// empty when only the plain text is known.
+	Lyrics struct {
+		Title  string
+		Artist string
+		Plain  string
+		Synced []LyricLine
+		Source string // name of the provider that found them
+	}
+
+	// LyricLine is one line of synced lyrics.
+	LyricLine struct {
+		At   time.Duration // offset from the start of the track
+		Text string
+	}
+
+	LyricsProvider interface {
+		Name() string
+		// GetLyrics looks up lyrics for a search query, usually
+		// "artist title". duration is the track length in seconds, or 0
+		// when unknown, and helps to pick the right version.
+		GetLyrics(ctx context.Context, query string, duration int) (*Lyrics, error)
+	}
+
+	// RadioStation is an internet radio station found in a directory.
+	RadioStation struct {
+		ID      string
+		Name    string
+		URL     string // stream url
+		Country string
+		Tags    []string
+		Codec   string
+		Bitrate int // kbps, 0 when unknown
+		Favicon string
+		Source  string // name of the provider that listed it
+	}
+
+	RadioProvider interface {
+		Name() string
+		// Search returns up to limit stations matching query, by name,
+		// tag or country.
+		Search(ctx context.Context, query string, limit int) ([]*RadioStation, error)
+	}
+)
+
+const (
+	EndFinished EndReason = "finished"
+	EndSkipped  EndReason = "skipped"
+	EndStopped  EndReason = "stopped"
 )
@@ -0,0 +1,48 @@
+//go:build !cgo || nontgcalls
+
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+// Without cgo, or with the nontgcalls tag, core is built without ntgcalls
+// so it can be tested on machines that don't have libntgcalls:
+//
+//	go test -race -tags nontgcalls ./internal/core/
+//
+// Assistants get no voice chat context and rooms need a Player from
+// NewPlayer, such as a FakePlayer.
+
+// NtgContext stands in for the ntgcalls context in builds without it.
+type NtgContext struct{}
+
+func (*NtgContext) Close() {}
+
+func newNtgContext(*telegram.Client) *NtgContext {
+	return &NtgContext{}
+}
+
+func newNtgPlayer(*Assistant) Player {
+	panic("core: built without ntgcalls, set NewPlayer to a Player such as FakePlayer")
+}
@@ -1,3 +1,5 @@
+//go:build cgo && !nontgcalls
+
 /*
  * This file is part of YukkiMusic.
  *
@@ -32,7 +34,20 @@ type NtgPlayer struct {
 }
 
 func (p *NtgPlayer) Play(r *RoomState) error {
-	desc := getMediaDescription(r.fpath, r.position, r.speed, r.track.Video)
+	af := r.audioFilter()
+	trackAF := joinFilters(gainFilter(r.track), af)
+
+	if r.fadeFrom != "" && !r.track.Video {
+		desc := getCrossfadeDescription(
+			r.fadeFrom, r.fadePos, joinFilters(r.fadeGain, af),
+			r.fpath, trackAF, r.crossfade,
+		)
+		return p.Ntg.Play(r.chatID, desc)
+	}
+
+	desc := getMediaDescription(
+		r.fpath, r.position, r.speed, trackAF, r.track.Video, r.track.IsLive,
+	)
 	return p.Ntg.Play(r.chatID, desc)
 }
 
@@ -60,13 +75,11 @@ func getMediaDescription(
 	url string,
 	pos int,
 	speed float64,
+	af string,
 	isVideo bool,
+	isLive bool,
 ) ntgcalls.MediaDescription {
-	if speed < 0.5 {
-		speed = 0.5
-	} else if speed > 4.0 {
-		speed = 4.0
-	}
+	speed = clampSpeed(speed)
 
 	audio := &ntgcalls.AudioDescription{
 		MediaSource:  ntgcalls.MediaSourceShell,
@@ -76,11 +89,16 @@ func getMediaDescription(
 
 	baseCmd := "ffmpeg "
 
-	if isStreamURL(url) {
+	// Live sources are never downloaded, ffmpeg reads them from the
+	// network and reconnects on its own, also when the server ends the
+	// connection. They cannot be seeked, a replay joins at the live edge.
+	if isLive {
+		baseCmd += "-reconnect 1 -reconnect_streamed 1 -reconnect_at_eof 1 -reconnect_delay_max 5 "
+	} else if isStreamURL(url) {
 		baseCmd += "-reconnect 1 -reconnect_streamed 1 -reconnect_delay_max 5 "
 	}
 
-	if pos > 0 {
+	if pos > 0 && !isLive {
 		baseCmd += "-ss " + strconv.Itoa(pos) + " "
 	}
 
@@ -88,12 +106,9 @@ func getMediaDescription(
 
 	// Audio pipeline
 	audioCmd := baseCmd
-	audioCmd += "-filter:a \"atempo=" + strconv.FormatFloat(
-		speed,
-		'f',
-		2,
-		64,
-	) + "\" "
+	if af != "" {
+		audioCmd += "-filter:a \"" + af + "\" "
+	}
 	audioCmd += "-f s16le -ac " + strconv.Itoa(int(audio.ChannelCount)) + " "
 	audioCmd += "-ar " + strconv.Itoa(int(audio.SampleRate)) + " "
 	audioCmd += "pipe:1"
@@ -126,3 +141,45 @@ func getMediaDescription(
 		Camera:     video,
 	}
 }
+
+// getCrossfadeDescription plays the rest of from, starting at fromPos,
+// overlapping its last seconds with the beginning of to. Each input goes
+// through its own audio filter chain.
+func getCrossfadeDescription(
+	from string,
+	fromPos int,
+	fromAF string,
+	to string,
+	toAF string,
+	seconds int,
+) ntgcalls.MediaDescription {
+	audio := &ntgcalls.AudioDescription{
+		MediaSource:  ntgcalls.MediaSourceShell,
+		SampleRate:   96000,
+		ChannelCount: 2,
+	}
+
+	if fromAF == "" {
+		fromAF = "anull"
+	}
+	if toAF == "" {
+		toAF = "anull"
+	}
+
+	cmd := "ffmpeg -v warning "
+	if fromPos > 0 {
+		cmd += "-ss " + strconv.Itoa(fromPos) + " "
+	}
+	cmd += "-i \"" + from + "\" "
+	cmd += "-i \"" + to + "\" "
+	cmd += "-filter_complex \"[0:a]" + fromAF + "[a0];[1:a]" + toAF + "[a1];"
+	cmd += "[a0][a1]acrossfade=d=" + strconv.Itoa(seconds) + "\" "
+	cmd += "-f s16le -ac " + strconv.Itoa(int(audio.ChannelCount)) + " "
+	cmd += "-ar " + strconv.Itoa(int(audio.SampleRate)) + " "
+	cmd += "pipe:1"
+	audio.Input = cmd
+
+	return ntgcalls.MediaDescription{
+		Microphone: audio,
+	}
+}
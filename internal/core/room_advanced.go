@@ -32,6 +32,14 @@ const (
 	seekSafetyMargin = 5
 )
 
+// MinVolume and MaxVolume bound the stream volume in percent.
+const (
+	MinVolume = 1
+	MaxVolume = 200
+)
+
+var GetVolume func(chatID int64) (int, error) // overwritten from main.go
+
 type playbackSnapshot struct {
 	position int
 	paused   bool
@@ -48,6 +56,10 @@ func (r *RoomState) Seek(seconds int) error {
 		return fmt.Errorf("no track to seek")
 	}
 
+	if r.track.IsLive {
+		return fmt.Errorf("cannot seek a live stream")
+	}
+
 	r.parse()
 
 	if seconds > 0 && r.track.Duration-r.position <= seekEndThreshold {
@@ -75,6 +87,9 @@ func (r *RoomState) executeSeek(seconds int) error {
 		r.p.Unmute(r)
 	}
 
+	r.scheduleCrossfade()
+	r.persist()
+	r.emit(EventSeeked)
 	return nil
 }
 
@@ -158,6 +173,9 @@ func (r *RoomState) executeSpeedChange(
 	}
 
 	r.scheduleSpeedReset(speed, timeAfterNormal)
+	r.scheduleCrossfade()
+	r.persist()
+	r.emit(EventSpeedChanged)
 	return nil
 }
 
@@ -195,9 +213,71 @@ func (r *RoomState) resetSpeedToNormal() {
 		r.speed = 1.0
 		r.p.Play(r)
 		r.updatedAt = time.Now().Unix()
+		r.scheduleCrossfade()
+		r.persist()
 	}
 }
 
+// SetVolume sets the stream volume in percent, restarting playback at the
+// current position when a track is playing.
+func (r *RoomState) SetVolume(volume int) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if volume < MinVolume || volume > MaxVolume {
+		return fmt.Errorf(
+			"invalid volume: must be between %d and %d",
+			MinVolume,
+			MaxVolume,
+		)
+	}
+
+	if r.volume == volume {
+		return nil
+	}
+	if r.track == nil {
+		// nothing to restart, the next stream starts with it
+		r.volume = volume
+		return nil
+	}
+
+	prev := r.volume
+	r.volume = volume
+	if err := r.restartStream(); err != nil {
+		r.volume = prev
+		return err
+	}
+	return nil
+}
+
+func (r *RoomState) Volume() int {
+	r.RLock()
+	defer r.RUnlock()
+	return r.volume
+}
+
+// restartStream plays the current track again from the current position
+// so changed filters take effect. The caller must hold r's lock.
+func (r *RoomState) restartStream() error {
+	if r.track == nil || r.fpath == "" {
+		return fmt.Errorf("no track is playing")
+	}
+
+	r.parse()
+	r.playing = true
+	r.paused = false
+	r.muted = false
+	r.updatedAt = time.Now().Unix()
+
+	if err := r.p.Play(r); err != nil {
+		return err
+	}
+
+	r.scheduleCrossfade()
+	r.persist()
+	return nil
+}
+
 // Mute mutes playback with optional auto-unmute
 func (r *RoomState) Mute(unmuteAfter ...time.Duration) (bool, error) {
 	if r.IsMuted() {
@@ -0,0 +1,114 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"time"
+
+	"github.com/Laky-64/gologging"
+)
+
+var (
+	GetCrossfade func(chatID int64) (int, error) // overwritten from main.go
+	OnCrossfade  func(chatID int64)              // overwritten by modules.Init
+)
+
+// MaxCrossfade is the longest crossfade in seconds.
+const MaxCrossfade = 12
+
+// SetCrossfade sets how many seconds consecutive tracks overlap, 0 to
+// turn it off.
+func (r *RoomState) SetCrossfade(seconds int) {
+	r.Lock()
+	defer r.Unlock()
+	r.crossfade = seconds
+	r.scheduleCrossfade()
+}
+
+func (r *RoomState) Crossfade() int {
+	r.RLock()
+	defer r.RUnlock()
+	return r.crossfade
+}
+
+// scheduleCrossfade arms the timer that starts the next track while the
+// current one is fading out. The caller must hold r's lock.
+func (r *RoomState) scheduleCrossfade() {
+	if r.fadeTimer != nil {
+		r.fadeTimer.Stop()
+		r.fadeTimer = nil
+	}
+
+	if r.crossfade <= 0 || OnCrossfade == nil || r.track == nil ||
+		r.track.IsLive || r.track.Video || isStreamURL(r.fpath) ||
+		r.track.Duration <= 2*r.crossfade {
+		return
+	}
+
+	r.fadeTimer = time.AfterFunc(r.untilCrossfade(), r.crossfadeNow)
+}
+
+// untilCrossfade must be called with the lock held.
+func (r *RoomState) untilCrossfade() time.Duration {
+	r.parse()
+	speed := r.speed
+	if speed <= 0 {
+		speed = 1.0
+	}
+	remaining := float64(r.track.Duration-r.position) / speed
+	return time.Duration((remaining - float64(r.crossfade)) * float64(time.Second))
+}
+
+func (r *RoomState) crossfadeNow() {
+	r.Lock()
+
+	if r.track == nil || !r.playing || r.crossfade <= 0 {
+		r.fadeTimer = nil
+		r.Unlock()
+		return
+	}
+
+	// paused or slowed down since the timer was set
+	d := r.untilCrossfade()
+	if r.paused || d > time.Second {
+		r.fadeTimer = time.AfterFunc(max(d, time.Second), r.crossfadeNow)
+		r.Unlock()
+		return
+	}
+	r.fadeTimer = nil
+
+	// too late to overlap, or no next track ready: the current one just
+	// ends normally
+	next := r.peekNext()
+	if d < 0 || r.loop > 0 || next == nil || next.Video || next.IsLive ||
+		!r.preloadReady() || r.scheduledTimers.stopsAfterTrack() {
+		r.Unlock()
+		return
+	}
+
+	r.fadeFrom = r.fpath
+	r.fadePos = r.position
+	r.fadeGain = gainFilter(r.track)
+	chatID := r.chatID
+	r.Unlock()
+
+	gologging.DebugF("Crossfading into the next track in %d", chatID)
+	OnCrossfade(chatID)
+}
@@ -0,0 +1,219 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+)
+
+// Effects lists the audio effect presets in the order they are applied.
+// Pitch effects keep the tempo, so positions, seeks and the progress bar
+// stay right; combine them with /speed for the sped-up variants.
+var Effects = []string{
+	"bassboost",
+	"nightcore",
+	"vaporwave",
+	"8d",
+	"karaoke",
+	"loudnorm",
+}
+
+var effectFilters = map[string]string{
+	"bassboost": "bass=g=10:f=110:w=0.6",
+	"nightcore": "aresample=48000,asetrate=48000*1.25,aresample=48000,atempo=0.8",
+	"vaporwave": "aresample=48000,asetrate=48000*0.8,aresample=48000,atempo=1.25",
+	"8d":        "apulsator=hz=0.125",
+	"karaoke":   "pan=stereo|c0=0.5*c0-0.5*c1|c1=0.5*c1-0.5*c0",
+	"loudnorm":  "loudnorm=I=-16:TP=-1.5:LRA=11",
+}
+
+// EQBands are the centre frequencies (Hz) of the equalizer bands.
+var EQBands = []int{31, 62, 125, 250, 500, 1000, 2000, 4000, 8000, 16000}
+
+// MaxEQGain is the largest boost or cut (dB) of a single band.
+const MaxEQGain = 12.0
+
+func IsEffect(name string) bool {
+	_, ok := effectFilters[name]
+	return ok
+}
+
+func (r *RoomState) Effects() []string {
+	r.RLock()
+	defer r.RUnlock()
+	return slices.Clone(r.effects)
+}
+
+// EQ returns the gain of every band in EQBands, nil when the equalizer
+// is flat.
+func (r *RoomState) EQ() []float64 {
+	r.RLock()
+	defer r.RUnlock()
+	return slices.Clone(r.eq)
+}
+
+// ToggleEffect turns the preset name on or off and restarts playback at
+// the current position. It reports whether the effect is now enabled.
+func (r *RoomState) ToggleEffect(name string) (bool, error) {
+	if !IsEffect(name) {
+		return false, fmt.Errorf("unknown effect: %s", name)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	effects := slices.DeleteFunc(slices.Clone(r.effects), func(e string) bool {
+		return e == name
+	})
+	enabled := len(effects) == len(r.effects)
+	if enabled {
+		effects = append(effects, name)
+	}
+	return enabled, r.applyFilters(sortEffects(effects), r.eq)
+}
+
+// SetEQ sets the equalizer gains, one per band of EQBands. Missing bands
+// are flat; an all-zero or empty slice turns the equalizer off.
+func (r *RoomState) SetEQ(gains []float64) error {
+	if len(gains) > len(EQBands) {
+		return fmt.Errorf("the equalizer has only %d bands", len(EQBands))
+	}
+
+	eq := make([]float64, len(EQBands))
+	flat := true
+	for i, g := range gains {
+		if g < -MaxEQGain || g > MaxEQGain {
+			return fmt.Errorf(
+				"invalid gain %.1f: must be between -%.0f and %.0f dB",
+				g,
+				MaxEQGain,
+				MaxEQGain,
+			)
+		}
+		eq[i] = g
+		flat = flat && g == 0
+	}
+	if flat {
+		eq = nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	return r.applyFilters(r.effects, eq)
+}
+
+// ClearEffects turns off every effect and the equalizer.
+func (r *RoomState) ClearEffects() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.effects) == 0 && r.eq == nil {
+		return nil
+	}
+	return r.applyFilters(nil, nil)
+}
+
+// applyFilters switches to the given effects and restarts playback at the
+// current position, like a speed change. The caller must hold r's lock.
+func (r *RoomState) applyFilters(effects []string, eq []float64) error {
+	if r.track == nil || r.fpath == "" {
+		return fmt.Errorf("no track to apply effects to")
+	}
+
+	prevEffects, prevEQ := r.effects, r.eq
+	r.effects = effects
+	r.eq = eq
+
+	if err := r.restartStream(); err != nil {
+		r.effects, r.eq = prevEffects, prevEQ
+		return err
+	}
+	return nil
+}
+
+// audioFilter returns the ffmpeg audio filter chain of the room. The
+// caller must hold r's lock.
+func (r *RoomState) audioFilter() string {
+	filters := []string{}
+	if tempo := buildAudioFilter(clampSpeed(r.speed)); tempo != "" {
+		filters = append(filters, tempo)
+	}
+
+	for i, g := range r.eq {
+		if g == 0 || i >= len(EQBands) {
+			continue
+		}
+		filters = append(filters, fmt.Sprintf(
+			"equalizer=f=%d:t=o:w=1:g=%s",
+			EQBands[i],
+			strconv.FormatFloat(g, 'f', 1, 64),
+		))
+	}
+
+	for _, e := range r.effects {
+		if f, ok := effectFilters[e]; ok {
+			filters = append(filters, f)
+		}
+	}
+
+	if r.volume > 0 && r.volume != 100 {
+		filters = append(filters, fmt.Sprintf("volume=%.2f", float64(r.volume)/100))
+	}
+	return strings.Join(filters, ",")
+}
+
+func sortEffects(effects []string) []string {
+	slices.SortFunc(effects, func(a, b string) int {
+		return slices.Index(Effects, a) - slices.Index(Effects, b)
+	})
+	return effects
+}
+
+func clampSpeed(speed float64) float64 {
+	return min(max(speed, minSpeed), maxSpeed)
+}
+
+// ApplyTrackGain restarts the streams of trackID at their current
+// position, so a loudness gain measured after they started takes effect
+// right away. Paused rooms pick it up on their next stream start.
+func ApplyTrackGain(trackID string) {
+	roomsMu.RLock()
+	all := make([]*RoomState, 0, len(rooms))
+	for _, r := range rooms {
+		all = append(all, r)
+	}
+	roomsMu.RUnlock()
+
+	for _, r := range all {
+		r.Lock()
+		if r.track != nil && r.track.ID == trackID && r.playing && !r.paused &&
+			!isStreamURL(r.fpath) {
+			if err := r.restartStream(); err != nil {
+				gologging.DebugF("Failed to apply loudness gain in %d: %v", r.chatID, err)
+			}
+		}
+		r.Unlock()
+	}
+}
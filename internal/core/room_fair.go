@@ -0,0 +1,107 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"math"
+	"math/rand"
+)
+
+var GetFairQueue func(chatID int64) (bool, error) // overwritten from main.go
+
+// SettingsChatID returns the chat whose settings a room follows: the group
+// a channel play room is linked to, the room's own chat otherwise.
+var SettingsChatID func(roomID int64) int64 // overwritten from main.go
+
+func settingsChatID(roomID int64) int64 {
+	if SettingsChatID == nil {
+		return roomID
+	}
+	return SettingsChatID(roomID)
+}
+
+// SetFairQueue toggles round-robin between requesters when picking the
+// next track.
+func (r *RoomState) SetFairQueue(enabled bool) {
+	r.Lock()
+	defer r.Unlock()
+	r.fairQueue = enabled
+	r.prefetch()
+}
+
+func (r *RoomState) FairQueue() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return r.fairQueue
+}
+
+// QueuedBy counts the queued tracks requested by userID.
+func (r *RoomState) QueuedBy(userID int64) int {
+	r.RLock()
+	defer r.RUnlock()
+
+	n := 0
+	for _, t := range r.queue {
+		if t.RequesterID == userID {
+			n++
+		}
+	}
+	return n
+}
+
+// fairNextIndex returns the first queued track of the requester whose
+// last turn is the oldest, so requesters take turns no matter how many
+// tracks each of them queued. The caller must hold r's lock.
+func (r *RoomState) fairNextIndex() int {
+	next := int64(0)
+	oldest := int64(math.MaxInt64)
+	seen := make(map[int64]bool)
+
+	for _, t := range r.queue {
+		if seen[t.RequesterID] {
+			continue
+		}
+		seen[t.RequesterID] = true
+		if turn := r.fairTurns[t.RequesterID]; turn < oldest {
+			next, oldest = t.RequesterID, turn
+		}
+	}
+
+	var indexes []int
+	for i, t := range r.queue {
+		if t.RequesterID == next {
+			indexes = append(indexes, i)
+		}
+	}
+	if r.shuffle {
+		return indexes[rand.Intn(len(indexes))]
+	}
+	return indexes[0]
+}
+
+// markTurn records that a track of requesterID started playing. The
+// caller must hold r's lock.
+func (r *RoomState) markTurn(requesterID int64) {
+	if r.fairTurns == nil {
+		r.fairTurns = make(map[int64]int64)
+	}
+	r.fairSeq++
+	r.fairTurns[requesterID] = r.fairSeq
+}
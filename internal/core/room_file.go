@@ -23,12 +23,16 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/Laky-64/gologging"
 
 	state "main/internal/core/models"
 )
 
+// fadeGrace is how long a crossfaded file is kept after the fade ends.
+const fadeGrace = 5 * time.Second
+
 // check if a track is used in any room (other than the given room)
 func isTrackUsed(trackID string, skipChatID int64) bool {
 	for _, room := range rooms {
@@ -40,7 +44,31 @@ func isTrackUsed(trackID string, skipChatID int64) bool {
 			return true
 		}
 
-		if isTrackInQueue(trackID, room.queue, 2) {
+		if isTrackInQueue(trackID, room.queue, 2) || room.isPreloading(trackID) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrackUsedAnywhere is isTrackUsed for goroutines that hold no locks: it
+// takes each room's lock in turn instead of reading the rooms unlocked.
+func isTrackUsedAnywhere(trackID string) bool {
+	roomsMu.RLock()
+	all := make([]*RoomState, 0, len(rooms))
+	for _, room := range rooms {
+		all = append(all, room)
+	}
+	roomsMu.RUnlock()
+
+	for _, room := range all {
+		room.RLock()
+		used := isRoomEligible(room, 0) &&
+			(room.track.ID == trackID ||
+				isTrackInQueue(trackID, room.queue, 2) ||
+				room.isPreloading(trackID))
+		room.RUnlock()
+		if used {
 			return true
 		}
 	}
@@ -75,6 +103,20 @@ func (r *RoomState) releaseFile() {
 	used := isTrackUsed(r.track.ID, r.chatID)
 	roomsMu.RUnlock()
 
+	if !used && r.fadeFrom == r.fpath {
+		// the next track crossfades from this file, remove it later
+		path, trackID, chatID := r.fpath, r.track.ID, r.chatID
+		time.AfterFunc(time.Duration(r.crossfade)*time.Second+fadeGrace, func() {
+			roomsMu.RLock()
+			used := isTrackUsed(trackID, chatID)
+			roomsMu.RUnlock()
+			if !used {
+				os.Remove(path)
+			}
+		})
+		return
+	}
+
 	if !used {
 		if err := os.Remove(r.fpath); err != nil && !os.IsNotExist(err) {
 			gologging.ErrorF("failed to remove file %s: %v", r.fpath, err)
@@ -108,20 +150,25 @@ func (r *RoomState) cleanupFile() {
 			continue
 		}
 
-		pattern := filepath.Join("downloads", t.ID+".*")
-		matches, err := filepath.Glob(pattern)
-		if err != nil {
-			gologging.ErrorF("glob failed for %s: %v", pattern, err)
-			continue
-		}
+		removeTrackFiles(t.ID)
+	}
+	roomsMu.RUnlock()
+}
 
-		for _, f := range matches {
-			if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
-				gologging.ErrorF("failed to remove file %s: %v", f, err)
-			} else {
-				gologging.DebugF("removed unused file: %s", f)
-			}
+// removeTrackFiles deletes every file downloaded for trackID.
+func removeTrackFiles(trackID string) {
+	pattern := filepath.Join("downloads", trackID+".*")
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		gologging.ErrorF("glob failed for %s: %v", pattern, err)
+		return
+	}
+
+	for _, f := range matches {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			gologging.ErrorF("failed to remove file %s: %v", f, err)
+		} else {
+			gologging.DebugF("removed unused file: %s", f)
 		}
 	}
-	roomsMu.RUnlock()
 }
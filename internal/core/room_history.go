@@ -0,0 +1,80 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"time"
+
+	state "main/internal/core/models"
+)
+
+var RecordHistory func(e *state.HistoryEntry) error // overwritten from main.go
+
+// SetEndReason overrides how the current track is recorded once it's
+// replaced or stopped, e.g. EndSkipped before a skip.
+func (r *RoomState) SetEndReason(reason state.EndReason) {
+	r.Lock()
+	defer r.Unlock()
+	r.endReason = reason
+}
+
+// recordEnd publishes EventTrackEnded and adds the current track to the
+// history. It must be called with the lock held, before r.track changes.
+// def is used unless SetEndReason was called for this track.
+func (r *RoomState) recordEnd(def state.EndReason) {
+	reason := r.endReason
+	r.endReason = ""
+
+	if r.track == nil || r.startedAt == 0 {
+		return
+	}
+	if reason == "" {
+		reason = def
+	}
+
+	r.parse()
+	e := r.event(EventTrackEnded)
+	e.Reason = reason
+	publish(e)
+	if reason == state.EndSkipped {
+		e.Type = EventSkipped
+		publish(e)
+	}
+
+	if RecordHistory == nil {
+		r.startedAt = 0
+		return
+	}
+
+	now := time.Now()
+	entry := &state.HistoryEntry{
+		ID:          now.UnixNano(),
+		Track:       r.track,
+		ChatID:      r.chatID,
+		RequesterID: r.track.RequesterID,
+		StartedAt:   r.startedAt,
+		EndedAt:     now.Unix(),
+		Played:      r.position,
+		Reason:      reason,
+	}
+	r.startedAt = 0
+
+	go RecordHistory(entry)
+}
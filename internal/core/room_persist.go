@@ -0,0 +1,184 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Laky-64/gologging"
+
+	state "main/internal/core/models"
+)
+
+var (
+	SaveRoomSnapshot   func(s *state.RoomSnapshot) error // overwritten from main.go
+	DeleteRoomSnapshot func(chatID int64) error          // overwritten from main.go
+)
+
+const (
+	// snapshotDelay coalesces bursts of state changes into a single write.
+	snapshotDelay = 2 * time.Second
+	// snapshotInterval keeps the stored position fresh while a track plays.
+	snapshotInterval = 15 * time.Second
+)
+
+// persist schedules a snapshot write. The caller must hold r's lock.
+func (r *RoomState) persist() {
+	if SaveRoomSnapshot == nil {
+		return
+	}
+	r.armPersist(snapshotDelay)
+}
+
+// armPersist (re)schedules the next timed write. Writes of timers that
+// were replaced or stopped meanwhile are skipped through persistGen, even
+// when they already fired. The caller must hold r's lock.
+func (r *RoomState) armPersist(d time.Duration) {
+	r.stopPersist()
+	gen := r.persistGen
+	r.persistTimer = time.AfterFunc(d, func() {
+		r.writeSnapshot(gen)
+	})
+}
+
+// stopPersist cancels the pending timed write. The caller must hold r's
+// lock.
+func (r *RoomState) stopPersist() {
+	r.persistGen++
+	if r.persistTimer != nil {
+		r.persistTimer.Stop()
+		r.persistTimer = nil
+	}
+}
+
+// writeSnapshot stores the current state, or deletes the stored one when
+// nothing plays. gen is the persistGen of the timer that fired, timed
+// writes keep refreshing the stored position while a track plays. A gen
+// of 0 writes once, unconditionally.
+func (r *RoomState) writeSnapshot(gen uint64) {
+	r.persistMu.Lock()
+	defer r.persistMu.Unlock()
+
+	r.Lock()
+	if r.destroyed || (gen != 0 && gen != r.persistGen) {
+		r.Unlock()
+		return
+	}
+	r.parse()
+	snap := r.snapshot()
+	if gen != 0 {
+		r.persistTimer = nil
+		if r.track != nil && r.playing && !r.paused {
+			r.armPersist(snapshotInterval)
+		}
+	}
+	r.Unlock()
+
+	var err error
+	if snap.Track == nil {
+		if DeleteRoomSnapshot == nil {
+			return
+		}
+		err = DeleteRoomSnapshot(snap.ChatID)
+	} else {
+		err = SaveRoomSnapshot(snap)
+	}
+	if err != nil {
+		gologging.ErrorF("Failed to persist room %d: %v", snap.ChatID, err)
+	}
+}
+
+func (r *RoomState) snapshot() *state.RoomSnapshot {
+	q := make([]*state.Track, len(r.queue))
+	copy(q, r.queue)
+
+	return &state.RoomSnapshot{
+		ChatID:    r.chatID,
+		Track:     r.track,
+		Position:  r.position,
+		Queue:     q,
+		Speed:     r.speed,
+		Effects:   slices.Clone(r.effects),
+		EQ:        slices.Clone(r.eq),
+		Volume:    r.volume,
+		Loop:      r.loop,
+		Shuffle:   r.shuffle,
+		CPlay:     r.cplay,
+		UpdatedAt: time.Now().Unix(),
+	}
+}
+
+// forgetSnapshot cancels pending writes and removes the stored snapshot.
+// A write already in progress finishes first, later ones are dropped.
+func (r *RoomState) forgetSnapshot() {
+	r.Lock()
+	r.destroyed = true
+	r.stopPersist()
+	chatID := r.chatID
+	r.Unlock()
+
+	r.persistMu.Lock()
+	defer r.persistMu.Unlock()
+
+	if DeleteRoomSnapshot == nil {
+		return
+	}
+	if err := DeleteRoomSnapshot(chatID); err != nil {
+		gologging.ErrorF("Failed to delete snapshot of room %d: %v", chatID, err)
+	}
+}
+
+// Restore applies the queue and playback settings of a snapshot.
+// The snapshot's track has to be started separately with Play.
+func (r *RoomState) Restore(s *state.RoomSnapshot) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.queue = make([]*state.Track, len(s.Queue))
+	copy(r.queue, s.Queue)
+
+	r.speed = s.Speed
+	if r.speed < minSpeed || r.speed > maxSpeed {
+		r.speed = 1.0
+	}
+	r.effects = slices.DeleteFunc(slices.Clone(s.Effects), func(e string) bool {
+		return !IsEffect(e)
+	})
+	if len(s.EQ) == len(EQBands) {
+		r.eq = slices.Clone(s.EQ)
+	}
+	if s.Volume >= MinVolume && s.Volume <= MaxVolume {
+		r.volume = s.Volume
+	}
+	r.loop = s.Loop
+	r.shuffle = s.Shuffle
+	r.cplay = s.CPlay
+	r.persist()
+}
+
+// SaveSnapshot writes the current state right away, e.g. before a restart.
+// No further periodic writes are scheduled by it.
+func (r *RoomState) SaveSnapshot() {
+	if SaveRoomSnapshot == nil {
+		return
+	}
+	r.writeSnapshot(0)
+}
@@ -39,6 +39,12 @@ func (r *RoomState) Play(t *state.Track, path string, force ...bool) error {
 
 	if !forcePlay && r.playing && r.track != nil {
 		r.queue = append(r.queue, t)
+		e := r.event(EventQueued)
+		e.Track = t
+		e.Index = len(r.queue)
+		publish(e)
+		r.prefetch()
+		r.persist()
 		return nil
 	}
 
@@ -46,16 +52,29 @@ func (r *RoomState) Play(t *state.Track, path string, force ...bool) error {
 }
 
 func (r *RoomState) startPlayback(t *state.Track, path string) error {
+	if r.track != nil && r.track != t {
+		r.recordEnd(state.EndSkipped)
+	}
+
 	r.track = t
 	r.playing = true
 	r.fpath = path
 
-	if err := r.p.Play(r); err != nil {
+	err := r.p.Play(r)
+	r.fadeFrom = ""
+	if err != nil {
 		r.cleanupFailedPlayback()
 		return err
 	}
 
 	r.resetPlaybackState()
+	r.startedAt = r.updatedAt
+	r.resetSkipVotes()
+	r.markTurn(t.RequesterID)
+	r.scheduleCrossfade()
+	r.prefetch()
+	r.persist()
+	r.emit(EventTrackStarted)
 	return nil
 }
 
@@ -92,6 +111,8 @@ func (r *RoomState) Pause(autoResumeAfter ...time.Duration) (bool, error) {
 
 	r.updatePauseState()
 	r.scheduleAutoResume(autoResumeAfter)
+	r.persist()
+	r.emit(EventPaused)
 
 	return paused, nil
 }
@@ -136,6 +157,8 @@ func (r *RoomState) Resume() (bool, error) {
 
 	r.updateResumeState()
 	r.scheduledTimers.cancelScheduledResume()
+	r.persist()
+	r.emit(EventResumed)
 
 	return resumed, nil
 }
@@ -172,6 +195,8 @@ func (r *RoomState) executeReplay() error {
 	r.playing = true
 	r.scheduledTimers.cancelScheduledResume()
 	r.scheduledTimers.cancelScheduledUnmute()
+	r.persist()
+	r.emit(EventSeeked)
 
 	return nil
 }
@@ -185,6 +210,7 @@ func (r *RoomState) Stop() error {
 	gologging.DebugF("Stop Called from %s:%d", file, line)
 
 	err := r.p.Stop(r)
+	r.recordEnd(state.EndStopped)
 	r.clearPlaybackState()
 
 	return err
@@ -197,7 +223,16 @@ func (r *RoomState) clearPlaybackState() {
 	r.paused = false
 	r.muted = false
 	r.updatedAt = 0
+	r.resetSkipVotes()
+	r.cancelPrefetch()
+	r.fadeFrom = ""
+	if r.fadeTimer != nil {
+		r.fadeTimer.Stop()
+		r.fadeTimer = nil
+	}
+	r.stopPersist()
 	r.scheduledTimers.cancelScheduledUnmute()
 	r.scheduledTimers.cancelScheduledResume()
 	r.scheduledTimers.cancelScheduledSpeed()
+	r.scheduledTimers.cancelScheduledStop()
 }
@@ -0,0 +1,231 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Laky-64/gologging"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+)
+
+var DownloadTrack func(ctx context.Context, t *state.Track) (string, error) // overwritten from main.go
+
+// prefetchSlots bounds the prefetch downloads running at once across all
+// rooms.
+var prefetchSlots = make(chan struct{}, max(config.PrefetchWorkers, 1))
+
+// preloadJob is a background download of a queued track, started while
+// the current one is still playing.
+type preloadJob struct {
+	track  *state.Track
+	done   chan struct{}
+	path   string
+	err    error
+	cancel context.CancelFunc
+}
+
+// prefetchTargets returns the tracks worth downloading ahead: the one that
+// plays next, followed by the rest of the queue in order, up to
+// config.PrefetchTracks. The caller must hold r's lock.
+func (r *RoomState) prefetchTargets() []*state.Track {
+	if config.PrefetchTracks <= 0 || len(r.queue) == 0 {
+		return nil
+	}
+
+	next := r.peekNext()
+	targets := []*state.Track{next}
+	for _, t := range r.queue {
+		if len(targets) >= config.PrefetchTracks {
+			break
+		}
+		if t != next {
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// prefetch starts downloading the upcoming tracks and drops the downloads
+// of tracks that are no longer coming up. The caller must hold r's lock.
+func (r *RoomState) prefetch() {
+	if DownloadTrack == nil {
+		return
+	}
+
+	var wanted []string
+	var jobs []*preloadJob
+	for _, t := range r.prefetchTargets() {
+		if t.IsLive || t.ID == "" || slices.Contains(wanted, t.ID) {
+			continue
+		}
+		wanted = append(wanted, t.ID)
+
+		if job := r.findPreload(t.ID); job != nil {
+			jobs = append(jobs, job)
+		} else {
+			jobs = append(jobs, startPreload(t))
+		}
+	}
+
+	// replace the jobs before discarding, so the cleanup of a discarded
+	// job never sees it as still wanted
+	stale := r.setPreloads(jobs)
+	for _, job := range stale {
+		if !slices.Contains(wanted, job.track.ID) {
+			discardPreload(job)
+		}
+	}
+}
+
+// setPreloads replaces r's prefetches and returns the previous ones. The
+// caller must hold r's lock.
+func (r *RoomState) setPreloads(jobs []*preloadJob) []*preloadJob {
+	r.preloadMu.Lock()
+	defer r.preloadMu.Unlock()
+
+	prev := r.preloads
+	r.preloads = jobs
+	return prev
+}
+
+func startPreload(t *state.Track) *preloadJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &preloadJob{
+		track:  t,
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer close(job.done)
+
+		select {
+		case prefetchSlots <- struct{}{}:
+		case <-ctx.Done():
+			job.err = ctx.Err()
+			return
+		}
+		defer func() { <-prefetchSlots }()
+
+		job.path, job.err = DownloadTrack(ctx, t)
+		if job.err != nil && ctx.Err() == nil {
+			gologging.DebugF("prefetch of %s failed: %v", t.ID, job.err)
+		}
+	}()
+	return job
+}
+
+// discardPreload cancels job and, once it has stopped, removes what it
+// downloaded unless some room still needs the track.
+func discardPreload(job *preloadJob) {
+	job.cancel()
+	go func() {
+		<-job.done
+
+		if !isTrackUsedAnywhere(job.track.ID) {
+			removeTrackFiles(job.track.ID)
+		}
+	}()
+}
+
+// findPreload must be called with the lock held.
+func (r *RoomState) findPreload(trackID string) *preloadJob {
+	for _, job := range r.preloads {
+		if job.track.ID == trackID {
+			return job
+		}
+	}
+	return nil
+}
+
+// isPreloading reports whether r is downloading or holds a prefetched
+// copy of trackID. It's safe to call without r's lock.
+func (r *RoomState) isPreloading(trackID string) bool {
+	r.preloadMu.Lock()
+	defer r.preloadMu.Unlock()
+
+	for _, job := range r.preloads {
+		if job.track.ID == trackID {
+			return true
+		}
+	}
+	return false
+}
+
+// preloadReady reports whether the next track is already downloaded.
+// The caller must hold r's lock.
+func (r *RoomState) preloadReady() bool {
+	next := r.peekNext()
+	if next == nil {
+		return false
+	}
+	job := r.findPreload(next.ID)
+	if job == nil {
+		return false
+	}
+	select {
+	case <-job.done:
+		return job.err == nil
+	default:
+		return false
+	}
+}
+
+// cancelPrefetch drops every prefetch of r. The caller must hold r's
+// lock.
+func (r *RoomState) cancelPrefetch() {
+	for _, job := range r.setPreloads(nil) {
+		discardPreload(job)
+	}
+}
+
+// Preloaded returns the file of t if it was downloaded in advance,
+// waiting for the download if it's still running. ok is false when t
+// wasn't prefetched or the download failed.
+func (r *RoomState) Preloaded(ctx context.Context, t *state.Track) (string, bool) {
+	r.Lock()
+	job := r.findPreload(t.ID)
+	if job == nil {
+		r.Unlock()
+		return "", false
+	}
+	var rest []*preloadJob
+	for _, j := range r.preloads {
+		if j != job {
+			rest = append(rest, j)
+		}
+	}
+	r.setPreloads(rest)
+	r.Unlock()
+
+	select {
+	case <-job.done:
+	case <-ctx.Done():
+		return "", false
+	}
+	if job.err != nil {
+		return "", false
+	}
+	return job.path, true
+}
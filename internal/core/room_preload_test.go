@@ -0,0 +1,103 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+// fakeDownloads makes DownloadTrack write an empty file per track into
+// a downloads directory under a temporary working directory.
+func fakeDownloads(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir("downloads", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	prev := DownloadTrack
+	DownloadTrack = func(ctx context.Context, tr *state.Track) (string, error) {
+		path := filepath.Join("downloads", tr.ID+".mp3")
+		return path, os.WriteFile(path, nil, 0o644)
+	}
+	t.Cleanup(func() { DownloadTrack = prev })
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestPrefetchDiscardRemovesDroppedTracks(t *testing.T) {
+	fakeDownloads(t)
+	r, _ := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 300))
+	for _, id := range []string{"b", "c"} {
+		r.Play(testTrack(id, 300), "")
+	}
+
+	b := filepath.Join("downloads", "b.mp3")
+	c := filepath.Join("downloads", "c.mp3")
+	waitFor(t, "prefetch", func() bool { return fileExists(b) && fileExists(c) })
+
+	r.RemoveFromQueue(1)
+	waitFor(t, "removal of c", func() bool { return !fileExists(c) })
+
+	if !fileExists(b) {
+		t.Fatal("prefetched file of the still queued b was removed")
+	}
+	path, ok := r.Preloaded(context.Background(), r.Queue()[0])
+	if !ok || path != b {
+		t.Fatalf("Preloaded(b) = %q, %v; want %q, true", path, ok, b)
+	}
+}
+
+func TestCancelPrefetchRemovesFiles(t *testing.T) {
+	fakeDownloads(t)
+	r, _ := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 300))
+	r.Play(testTrack("b", 300), "")
+
+	b := filepath.Join("downloads", "b.mp3")
+	waitFor(t, "prefetch", func() bool { return fileExists(b) })
+
+	r.Stop()
+	waitFor(t, "removal of b", func() bool { return !fileExists(b) })
+}
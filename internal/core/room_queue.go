@@ -22,6 +22,7 @@ package core
 
 import (
 	"math/rand"
+	"slices"
 	"time"
 
 	state "main/internal/core/models"
@@ -32,6 +33,8 @@ func (r *RoomState) NextTrack() *state.Track {
 	r.Lock()
 	defer r.Unlock()
 
+	r.recordEnd(state.EndFinished)
+
 	if r.shouldLoopCurrentTrack() {
 		return r.loopCurrentTrack()
 	}
@@ -56,18 +59,42 @@ func (r *RoomState) loopCurrentTrack() *state.Track {
 	r.muted = false
 	r.loop--
 	r.updatedAt = time.Now().Unix()
+	r.startedAt = r.updatedAt
+	r.resetSkipVotes()
+	r.persist()
 	return r.track
 }
 
 func (r *RoomState) dequeueNextTrack() *state.Track {
-	index := r.selectNextTrackIndex()
-	next := r.queue[index]
+	next := r.peekNext()
+	index := slices.Index(r.queue, next)
+	r.nextPick = nil
 	r.removeTrackAtIndex(index)
 	r.prepareNextTrack(next)
+	r.persist()
 	return next
 }
 
+// peekNext returns the track the next dequeue will take, nil if the queue
+// is empty. A random pick is kept until it's dequeued so preloading and
+// playback agree on it. The caller must hold r's lock.
+func (r *RoomState) peekNext() *state.Track {
+	if len(r.queue) == 0 {
+		return nil
+	}
+	if !r.shuffle {
+		return r.queue[r.selectNextTrackIndex()]
+	}
+	if r.nextPick == nil || !slices.Contains(r.queue, r.nextPick) {
+		r.nextPick = r.queue[r.selectNextTrackIndex()]
+	}
+	return r.nextPick
+}
+
 func (r *RoomState) selectNextTrackIndex() int {
+	if r.fairQueue {
+		return r.fairNextIndex()
+	}
 	if r.shuffle {
 		return rand.Intn(len(r.queue))
 	}
@@ -80,6 +107,7 @@ func (r *RoomState) removeTrackAtIndex(index int) {
 
 func (r *RoomState) prepareNextTrack(track *state.Track) {
 	r.track = track
+	r.resetSkipVotes()
 	r.position = 0
 	r.playing = false
 	r.paused = false
@@ -94,11 +122,15 @@ func (r *RoomState) RemoveFromQueue(index int) {
 
 	if index == -1 {
 		r.clearQueue()
+		r.prefetch()
+		r.persist()
 		return
 	}
 
 	if r.isValidQueueIndex(index) {
 		r.removeTrackAtIndex(index)
+		r.prefetch()
+		r.persist()
 	}
 }
 
@@ -120,6 +152,8 @@ func (r *RoomState) MoveInQueue(from, to int) {
 	}
 
 	r.executeMoveOperation(from, to)
+	r.prefetch()
+	r.persist()
 }
 
 func (r *RoomState) isValidMove(from, to int) bool {
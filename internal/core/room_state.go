@@ -36,6 +36,9 @@ var (
 	roomsMu sync.RWMutex
 )
 
+// Player streams a room into its voice chat. Play and Stop are called
+// with r's lock held and may read its playback fields; the other methods
+// are not always, so they must only use r.chatID.
 type Player interface {
 	Play(r *RoomState) error
 	Pause(r *RoomState) (bool, error)
@@ -45,6 +48,12 @@ type Player interface {
 	Unmute(r *RoomState) (bool, error)
 }
 
+// NewPlayer creates the Player for a new room. It can be replaced,
+// e.g. with a FakePlayer, before any room is created.
+var NewPlayer = func(chatID int64, ass *Assistant) Player {
+	return newNtgPlayer(ass)
+}
+
 type RoomState struct {
 	sync.RWMutex
 
@@ -58,12 +67,36 @@ type RoomState struct {
 	fpath     string
 	queue     []*state.Track
 	speed     float64
+	volume    int // percent
 	shuffle   bool
+	effects   []string  // enabled effect presets, in Effects order
+	eq        []float64 // gains per EQBands, nil when flat
 
 	loop   int
 	cplay  bool
 	mystic *telegram.NewMessage
 
+	persistTimer *time.Timer
+	persistGen   uint64          // bumped whenever persistTimer is replaced or stopped
+	persistMu    sync.Mutex      // orders snapshot writes against forgetSnapshot
+	destroyed    bool            // set by forgetSnapshot, no more snapshot writes
+	startedAt    int64           // unix time the current track started
+	endReason    state.EndReason // set by SetEndReason, consumed by recordEnd
+	skipVotes    map[int64]struct{}
+
+	fairQueue bool            // round-robin between requesters
+	fairTurns map[int64]int64 // requester id -> fairSeq of their last turn
+	fairSeq   int64
+
+	nextPick  *state.Track  // next track picked in advance while shuffling
+	preloads  []*preloadJob // prefetches of the upcoming tracks
+	preloadMu sync.Mutex    // guards preloads for readers from other rooms
+	crossfade int           // seconds consecutive tracks overlap
+	fadeTimer *time.Timer
+	fadeFrom  string // file the next Play crossfades from
+	fadePos   int
+	fadeGain  string // loudness gain filter of the file faded from
+
 	p Player
 	*scheduledTimers
 }
@@ -100,18 +133,34 @@ func GetRoom(chatID int64, ass *Assistant, create ...bool) (*RoomState, bool) {
 }
 
 func createNewRoom(chatID int64, ass *Assistant) (*RoomState, bool) {
+	fair := false
+	if GetFairQueue != nil {
+		fair, _ = GetFairQueue(settingsChatID(chatID))
+	}
+	crossfade := 0
+	if GetCrossfade != nil {
+		crossfade, _ = GetCrossfade(settingsChatID(chatID))
+	}
+	volume := 100
+	if GetVolume != nil {
+		if v, err := GetVolume(chatID); err == nil && v >= MinVolume && v <= MaxVolume {
+			volume = v
+		}
+	}
+
 	roomsMu.Lock()
 	defer roomsMu.Unlock()
 
 	room, exists := rooms[chatID]
 	if !exists {
 		room = &RoomState{
-			chatID: chatID,
-			queue:  []*state.Track{},
-			speed:  1.0,
-			p: &NtgPlayer{
-				Ntg: ass.Ntg,
-			},
+			chatID:    chatID,
+			queue:     []*state.Track{},
+			speed:     1.0,
+			volume:    volume,
+			fairQueue: fair,
+			crossfade: crossfade,
+			p:         NewPlayer(chatID, ass),
 		}
 		rooms[chatID] = room
 	}
@@ -201,18 +250,22 @@ func (r *RoomState) SetCPlay(isCPlay bool) {
 	r.Lock()
 	defer r.Unlock()
 	r.cplay = isCPlay
+	r.persist()
 }
 
 func (r *RoomState) SetLoop(loop int) {
 	r.Lock()
 	defer r.Unlock()
 	r.loop = loop
+	r.persist()
 }
 
 func (r *RoomState) SetShuffle(enabled bool) {
 	r.Lock()
 	defer r.Unlock()
 	r.shuffle = enabled
+	r.prefetch()
+	r.persist()
 }
 
 func (r *RoomState) SetMystic(m *telegram.NewMessage) {
@@ -224,6 +277,16 @@ func (r *RoomState) SetMystic(m *telegram.NewMessage) {
 	r.mystic = m
 }
 
+// UpdateMystic replaces the stored now playing message with an edited copy
+// of it, unlike SetMystic the message itself is kept.
+func (r *RoomState) UpdateMystic(m *telegram.NewMessage) {
+	r.Lock()
+	defer r.Unlock()
+	if m != nil && r.mystic != nil && r.mystic.ID == m.ID {
+		r.mystic = m
+	}
+}
+
 // State checks
 
 func (r *RoomState) IsCPlay() bool {
@@ -269,7 +332,9 @@ func (r *RoomState) parse() {
 
 	if r.playing && !r.paused {
 		r.position += int(elapsed * r.speed)
-		if r.position >= r.track.Duration {
+		// live streams have no end, their position is the time elapsed
+		if !r.track.IsLive && r.track.Duration > 0 &&
+			r.position >= r.track.Duration {
 			r.position = r.track.Duration
 			r.playing = false
 		}
@@ -284,6 +349,9 @@ func (r *RoomState) Destroy() {
 	r.Stop()
 	r.cleanupFile()
 	roomsMu.Lock()
-	defer roomsMu.Unlock()
 	delete(rooms, r.chatID)
+	roomsMu.Unlock()
+
+	r.forgetSnapshot()
+	publish(Event{Type: EventRoomDestroyed, ChatID: r.chatID})
 }
@@ -0,0 +1,499 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+var testChatID atomic.Int64
+
+// newTestRoom returns a fresh room whose player is a FakePlayer. The room
+// is destroyed and NewPlayer restored when the test ends.
+func newTestRoom(t *testing.T) (*RoomState, *FakePlayer) {
+	t.Helper()
+
+	fake := NewFakePlayer()
+	prev := NewPlayer
+	NewPlayer = func(int64, *Assistant) Player { return fake }
+
+	chatID := -1000000000000 - testChatID.Add(1)
+	r, _ := GetRoom(chatID, nil, true)
+	t.Cleanup(func() {
+		r.Destroy()
+		NewPlayer = prev
+	})
+	return r, fake
+}
+
+func testTrack(id string, duration int) *state.Track {
+	return &state.Track{ID: id, Title: id, Duration: duration}
+}
+
+// startTrack plays t right away on r.
+func startTrack(tb testing.TB, r *RoomState, t *state.Track) {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), t.ID+".mp3")
+	if err := r.Play(t, path, true); err != nil {
+		tb.Fatalf("Play(%s): %v", t.ID, err)
+	}
+}
+
+func queueIDs(r *RoomState) []string {
+	var ids []string
+	for _, t := range r.Queue() {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// near reports whether got is within a second of want, parse may
+// advance the position when a test crosses a second boundary.
+func near(got, want int) bool {
+	return got >= want && got <= want+1
+}
+
+func TestPlayQueuesWhilePlaying(t *testing.T) {
+	r, fake := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 200))
+	for _, id := range []string{"b", "c", "d"} {
+		if err := r.Play(testTrack(id, 200), ""); err != nil {
+			t.Fatalf("Play(%s): %v", id, err)
+		}
+	}
+
+	if got := r.Track().ID; got != "a" {
+		t.Fatalf("current track = %s, want a", got)
+	}
+	if got, want := queueIDs(r), []string{"b", "c", "d"}; !equalIDs(got, want) {
+		t.Fatalf("queue = %v, want %v", got, want)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 1 || calls[0].Method != "play" || calls[0].Track.ID != "a" {
+		t.Fatalf("player calls = %+v, want a single play of a", calls)
+	}
+	if !r.IsActiveChat() {
+		t.Fatal("room is not active while playing")
+	}
+}
+
+func TestNextTrackOrder(t *testing.T) {
+	r, _ := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 200))
+	for _, id := range []string{"b", "c"} {
+		r.Play(testTrack(id, 200), "")
+	}
+
+	for _, want := range []string{"b", "c"} {
+		next := r.NextTrack()
+		if next == nil || next.ID != want {
+			t.Fatalf("NextTrack() = %v, want %s", next, want)
+		}
+		if r.Track() != next {
+			t.Fatalf("current track is not the dequeued %s", want)
+		}
+	}
+	if next := r.NextTrack(); next != nil {
+		t.Fatalf("NextTrack() on empty queue = %s, want nil", next.ID)
+	}
+}
+
+func TestRemoveAndMoveInQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(r *RoomState)
+		want []string
+	}{
+		{"remove first", func(r *RoomState) { r.RemoveFromQueue(0) }, []string{"c", "d", "e"}},
+		{"remove last", func(r *RoomState) { r.RemoveFromQueue(3) }, []string{"b", "c", "d"}},
+		{"remove out of range", func(r *RoomState) { r.RemoveFromQueue(4) }, []string{"b", "c", "d", "e"}},
+		{"clear", func(r *RoomState) { r.RemoveFromQueue(-1) }, nil},
+		{"move down", func(r *RoomState) { r.MoveInQueue(0, 2) }, []string{"c", "d", "b", "e"}},
+		{"move up", func(r *RoomState) { r.MoveInQueue(3, 0) }, []string{"e", "b", "c", "d"}},
+		{"move to end", func(r *RoomState) { r.MoveInQueue(1, 3) }, []string{"b", "d", "e", "c"}},
+		{"move in place", func(r *RoomState) { r.MoveInQueue(1, 1) }, []string{"b", "c", "d", "e"}},
+		{"move out of range", func(r *RoomState) { r.MoveInQueue(0, 4) }, []string{"b", "c", "d", "e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRoom(t)
+			startTrack(t, r, testTrack("a", 200))
+			for _, id := range []string{"b", "c", "d", "e"} {
+				r.Play(testTrack(id, 200), "")
+			}
+
+			tt.edit(r)
+
+			if got := queueIDs(r); !equalIDs(got, tt.want) {
+				t.Fatalf("queue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopRepeatsCurrentTrack(t *testing.T) {
+	r, _ := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 200))
+	r.Play(testTrack("b", 200), "")
+	r.SetLoop(2)
+
+	for i, want := range []string{"a", "a", "b"} {
+		next := r.NextTrack()
+		if next == nil || next.ID != want {
+			t.Fatalf("NextTrack() #%d = %v, want %s", i+1, next, want)
+		}
+	}
+	if got := r.Loop(); got != 0 {
+		t.Fatalf("loop = %d after repeating, want 0", got)
+	}
+}
+
+func TestShufflePlaysEveryTrackOnce(t *testing.T) {
+	r, _ := newTestRoom(t)
+
+	startTrack(t, r, testTrack("a", 200))
+	ids := []string{"b", "c", "d", "e", "f", "g"}
+	for _, id := range ids {
+		r.Play(testTrack(id, 200), "")
+	}
+	r.SetShuffle(true)
+
+	seen := make(map[string]bool)
+	for range ids {
+		r.Lock()
+		peeked := r.peekNext()
+		again := r.peekNext()
+		r.Unlock()
+		if peeked != again {
+			t.Fatalf("peekNext changed its pick from %s to %s", peeked.ID, again.ID)
+		}
+
+		next := r.NextTrack()
+		if next != peeked {
+			t.Fatalf("NextTrack() = %s, peekNext said %s", next.ID, peeked.ID)
+		}
+		if seen[next.ID] {
+			t.Fatalf("%s was played twice", next.ID)
+		}
+		seen[next.ID] = true
+	}
+
+	if len(seen) != len(ids) {
+		t.Fatalf("played %d tracks, want %d", len(seen), len(ids))
+	}
+	if next := r.NextTrack(); next != nil {
+		t.Fatalf("NextTrack() on empty queue = %s, want nil", next.ID)
+	}
+}
+
+func TestSeek(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   int
+		seek    int
+		want    int
+		wantErr bool
+	}{
+		{"forward", 0, 60, 60, false},
+		{"backward", 100, -30, 70, false},
+		{"before start", 20, -60, 0, false},
+		{"past end", 100, 500, 300 - seekSafetyMargin, false},
+		{"forward near end", 300 - seekEndThreshold, 5, 300 - seekEndThreshold, true},
+		{"backward near end", 295, -15, 280, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, fake := newTestRoom(t)
+			startTrack(t, r, testTrack("a", 300))
+			if tt.start > 0 {
+				r.Lock()
+				r.position = tt.start
+				r.Unlock()
+			}
+
+			err := r.Seek(tt.seek)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Seek(%d) error = %v, wantErr %v", tt.seek, err, tt.wantErr)
+			}
+			if got := r.Position(); !near(got, tt.want) {
+				t.Fatalf("position = %d, want %d", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			call, _ := fake.LastCall()
+			if call.Method != "play" || !near(call.Position, tt.want) {
+				t.Fatalf("last player call = %+v, want play at %d", call, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeekRejectsLiveAndIdle(t *testing.T) {
+	r, _ := newTestRoom(t)
+	if err := r.Seek(10); err == nil {
+		t.Fatal("Seek without a track succeeded")
+	}
+
+	live := testTrack("live", 0)
+	live.IsLive = true
+	startTrack(t, r, live)
+	if err := r.Seek(10); err == nil {
+		t.Fatal("Seek on a live stream succeeded")
+	}
+}
+
+func TestSetSpeed(t *testing.T) {
+	r, fake := newTestRoom(t)
+	if err := r.SetSpeed(1.5); err == nil {
+		t.Fatal("SetSpeed without a track succeeded")
+	}
+
+	startTrack(t, r, testTrack("a", 300))
+	for _, speed := range []float64{minSpeed - 0.1, maxSpeed + 0.5} {
+		if err := r.SetSpeed(speed); err == nil {
+			t.Fatalf("SetSpeed(%.2f) succeeded", speed)
+		}
+	}
+	if got := r.Speed(); got != 1.0 {
+		t.Fatalf("speed = %.2f after invalid changes, want 1.00", got)
+	}
+
+	fake.Reset()
+	if err := r.SetSpeed(1.5); err != nil {
+		t.Fatalf("SetSpeed(1.5): %v", err)
+	}
+	if got := r.Speed(); got != 1.5 {
+		t.Fatalf("speed = %.2f, want 1.50", got)
+	}
+	call, ok := fake.LastCall()
+	if !ok || call.Method != "play" || call.Speed != 1.5 {
+		t.Fatalf("last player call = %+v, want play at 1.5x", call)
+	}
+
+	// setting the same speed again doesn't restart the stream
+	fake.Reset()
+	if err := r.SetSpeed(1.5); err != nil {
+		t.Fatalf("SetSpeed(1.5) again: %v", err)
+	}
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Fatalf("player calls = %+v, want none", calls)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	r, fake := newTestRoom(t)
+	startTrack(t, r, testTrack("a", 300))
+
+	if ok, err := r.Pause(); err != nil || !ok {
+		t.Fatalf("Pause() = %v, %v", ok, err)
+	}
+	if !r.IsPaused() {
+		t.Fatal("room is not paused")
+	}
+	if ok, err := r.Resume(); err != nil || !ok {
+		t.Fatalf("Resume() = %v, %v", ok, err)
+	}
+	if r.IsPaused() {
+		t.Fatal("room is still paused")
+	}
+
+	var methods []string
+	for _, c := range fake.Calls() {
+		methods = append(methods, c.Method)
+	}
+	if want := []string{"play", "pause", "resume"}; !equalIDs(methods, want) {
+		t.Fatalf("player calls = %v, want %v", methods, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	live := testTrack("live", 0)
+	live.IsLive = true
+
+	tests := []struct {
+		name        string
+		track       *state.Track
+		elapsed     int64
+		wantPos     int
+		wantPlaying bool
+	}{
+		{"advances", testTrack("a", 300), 30, 30, true},
+		{"clamps at the end", testTrack("a", 300), 400, 300, false},
+		{"live keeps going", live, 400, 400, true},
+		{"unknown duration keeps going", testTrack("radio", 0), 400, 400, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRoom(t)
+			startTrack(t, r, tt.track)
+
+			r.Lock()
+			r.updatedAt -= tt.elapsed
+			r.Unlock()
+
+			if got := r.IsActiveChat(); got != tt.wantPlaying {
+				t.Fatalf("IsActiveChat() = %v, want %v", got, tt.wantPlaying)
+			}
+			if got := r.Position(); !near(got, tt.wantPos) {
+				t.Fatalf("position = %d, want %d", got, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestLiveStreamControls(t *testing.T) {
+	r, fake := newTestRoom(t)
+
+	live := testTrack("live", 0)
+	live.IsLive = true
+	startTrack(t, r, live)
+
+	r.Lock()
+	r.updatedAt -= 120
+	r.Unlock()
+
+	if ok, err := r.Pause(); err != nil || !ok {
+		t.Fatalf("Pause() on a live stream = %v, %v", ok, err)
+	}
+	if ok, err := r.Resume(); err != nil || !ok {
+		t.Fatalf("Resume() on a live stream = %v, %v", ok, err)
+	}
+	if !r.IsActiveChat() {
+		t.Fatal("live stream is not active after two minutes")
+	}
+
+	if err := r.Stop(); err != nil {
+		t.Fatalf("Stop(): %v", err)
+	}
+	call, _ := fake.LastCall()
+	if call.Method != "stop" {
+		t.Fatalf("last player call = %+v, want stop", call)
+	}
+}
+
+func TestRoomFollowsLinkedGroupSettings(t *testing.T) {
+	const group = -1001
+	prevFair, prevFade, prevSettings := GetFairQueue, GetCrossfade, SettingsChatID
+	GetFairQueue = func(chatID int64) (bool, error) { return chatID == group, nil }
+	GetCrossfade = func(chatID int64) (int, error) {
+		if chatID == group {
+			return 6, nil
+		}
+		return 0, nil
+	}
+	t.Cleanup(func() {
+		GetFairQueue, GetCrossfade, SettingsChatID = prevFair, prevFade, prevSettings
+	})
+
+	// a channel play room reads the settings of the group it's linked to
+	SettingsChatID = func(int64) int64 { return group }
+	r, _ := newTestRoom(t)
+	if !r.FairQueue() || r.Crossfade() != 6 {
+		t.Fatal("channel room didn't take its settings from its group")
+	}
+
+	SettingsChatID = func(roomID int64) int64 { return roomID }
+	r, _ = newTestRoom(t)
+	if r.FairQueue() || r.Crossfade() != 0 {
+		t.Fatal("unlinked room took the settings of another chat")
+	}
+}
+
+func TestApplyTrackGainRestartsPlayingRooms(t *testing.T) {
+	r, fake := newTestRoom(t)
+	other, otherFake := newTestRoom(t)
+	startTrack(t, r, testTrack("a", 300))
+	startTrack(t, other, testTrack("b", 300))
+
+	r.Lock()
+	r.position = 42
+	r.Unlock()
+	fake.Reset()
+	otherFake.Reset()
+
+	ApplyTrackGain("a")
+
+	call, ok := fake.LastCall()
+	if !ok || call.Method != "play" || !near(call.Position, 42) {
+		t.Fatalf("last player call = %+v, want play at 42", call)
+	}
+	if calls := otherFake.Calls(); len(calls) != 0 {
+		t.Fatalf("room playing another track was restarted: %+v", calls)
+	}
+
+	// paused rooms aren't resumed by it
+	r.Pause()
+	fake.Reset()
+	ApplyTrackGain("a")
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Fatalf("paused room was restarted: %+v", calls)
+	}
+}
+
+func TestSleepRemainingWhileTimerChanges(t *testing.T) {
+	r, _ := newTestRoom(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 100 {
+			r.SetSleepTimer(time.Duration(i+1) * time.Minute)
+		}
+	}()
+	for range 100 {
+		r.SleepRemaining()
+	}
+	<-done
+
+	if d := r.SleepRemaining(); d <= 99*time.Minute || d > 100*time.Minute {
+		t.Fatalf("SleepRemaining = %v, want just under 100m", d)
+	}
+	r.SetSleepTimer(0)
+	if d := r.SleepRemaining(); d != 0 {
+		t.Fatalf("SleepRemaining after cancel = %v, want 0", d)
+	}
+}
@@ -21,14 +21,23 @@ package core
 
 import "time"
 
+// OnScheduledStop is called after a sleep timer or stop-after count has
+// destroyed a room.
+var OnScheduledStop func(r *RoomState) // overwritten by modules.Init
+
 type scheduledTimers struct {
 	scheduledUnmuteTimer *time.Timer
 	scheduledResumeTimer *time.Timer
 	scheduledSpeedTimer  *time.Timer
+	scheduledStopTimer   *time.Timer
 
 	scheduledUnmuteUntil time.Time
 	scheduledResumeUntil time.Time
 	scheduledSpeedUntil  time.Time
+	scheduledStopUntil   time.Time
+
+	// track endings left before the room stops, 0 when unset
+	stopAfterTracks int
 }
 
 func (st *scheduledTimers) RemainingUnmuteDuration() time.Duration {
@@ -52,6 +61,13 @@ func (st *scheduledTimers) RemainingSpeedDuration() time.Duration {
 	return time.Until(st.scheduledSpeedUntil)
 }
 
+func (st *scheduledTimers) RemainingStopDuration() time.Duration {
+	if st == nil || st.scheduledStopUntil.IsZero() {
+		return 0
+	}
+	return time.Until(st.scheduledStopUntil)
+}
+
 func (st *scheduledTimers) cancelScheduledUnmute() {
 	if st != nil && st.scheduledUnmuteTimer != nil {
 		st.scheduledUnmuteTimer.Stop()
@@ -75,3 +91,123 @@ func (st *scheduledTimers) cancelScheduledSpeed() {
 		st.scheduledSpeedUntil = time.Time{}
 	}
 }
+
+func (st *scheduledTimers) cancelScheduledStop() {
+	if st != nil {
+		if st.scheduledStopTimer != nil {
+			st.scheduledStopTimer.Stop()
+			st.scheduledStopTimer = nil
+		}
+		st.scheduledStopUntil = time.Time{}
+		st.stopAfterTracks = 0
+	}
+}
+
+// stopsAfterTrack tells that the current track is the last one before a
+// stop-after count ends playback.
+func (st *scheduledTimers) stopsAfterTrack() bool {
+	return st != nil && st.stopAfterTracks == 1
+}
+
+// SetSleepTimer stops the room once d has passed, replacing any sleep
+// timer or stop-after count. A zero d cancels both.
+func (r *RoomState) SetSleepTimer(d time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.scheduledTimers == nil {
+		r.scheduledTimers = &scheduledTimers{}
+	}
+	r.scheduledTimers.cancelScheduledStop()
+
+	if d > 0 {
+		r.scheduledStopUntil = time.Now().Add(d)
+		r.scheduledStopTimer = time.AfterFunc(d, r.sleepNow)
+	}
+}
+
+// SleepRemaining returns the time left on the sleep timer, 0 when none
+// is set.
+func (r *RoomState) SleepRemaining() time.Duration {
+	r.RLock()
+	defer r.RUnlock()
+	return r.scheduledTimers.RemainingStopDuration()
+}
+
+// SleepMinutes returns the time left on the sleep timer in minutes,
+// rounded up, 0 when none is set. The now playing markup shows it this
+// way so it only has to be redrawn once a minute.
+func (r *RoomState) SleepMinutes() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	d := r.scheduledTimers.RemainingStopDuration()
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Minute - 1) / time.Minute)
+}
+
+// SetStopAfter stops the room when n more tracks have finished, 1 being
+// the current one, replacing any sleep timer. Zero cancels both.
+func (r *RoomState) SetStopAfter(n int) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.scheduledTimers == nil {
+		r.scheduledTimers = &scheduledTimers{}
+	}
+	r.scheduledTimers.cancelScheduledStop()
+	r.stopAfterTracks = max(n, 0)
+}
+
+// StopAfter returns how many more tracks finish before the room stops,
+// 0 when no count is set.
+func (r *RoomState) StopAfter() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.scheduledTimers == nil {
+		return 0
+	}
+	return r.stopAfterTracks
+}
+
+// CountStopAfter counts a finished track towards the stop-after count
+// and reports whether that was the last one, the caller then stops the
+// room with StopScheduled.
+func (r *RoomState) CountStopAfter() bool {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.scheduledTimers == nil || r.stopAfterTracks == 0 {
+		return false
+	}
+	r.stopAfterTracks--
+	return r.stopAfterTracks == 0
+}
+
+// StopScheduled destroys the room, removing its files, and reports it
+// through OnScheduledStop.
+func (r *RoomState) StopScheduled() {
+	r.Destroy()
+	if OnScheduledStop != nil {
+		OnScheduledStop(r)
+	}
+}
+
+func (r *RoomState) sleepNow() {
+	r.Lock()
+	// cancelled or replaced while the timer was firing
+	due := !r.scheduledStopUntil.IsZero() &&
+		!time.Now().Before(r.scheduledStopUntil)
+	if due {
+		r.scheduledStopTimer = nil
+		r.scheduledStopUntil = time.Time{}
+	}
+	r.Unlock()
+
+	if due {
+		r.StopScheduled()
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package core
+
+import "slices"
+
+// AddSkipVote registers userID's vote to skip the current track and
+// counts the votes of users still in listeners. added is false if the user
+// already voted. passed is true once the count reaches needed, the votes
+// are cleared then so concurrent voters can't pass the same skip twice.
+func (r *RoomState) AddSkipVote(
+	userID int64,
+	listeners []int64,
+	needed int,
+) (votes int, added, passed bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.skipVotes == nil {
+		r.skipVotes = make(map[int64]struct{})
+	}
+	if _, ok := r.skipVotes[userID]; !ok {
+		r.skipVotes[userID] = struct{}{}
+		added = true
+	}
+
+	// listeners who voted and left don't count anymore
+	for id := range r.skipVotes {
+		if slices.Contains(listeners, id) {
+			votes++
+		}
+	}
+
+	if added && votes >= needed {
+		r.resetSkipVotes()
+		passed = true
+	}
+	return votes, added, passed
+}
+
+// resetSkipVotes must be called with the lock held whenever the current
+// track changes or starts over.
+func (r *RoomState) resetSkipVotes() {
+	r.skipVotes = nil
+}
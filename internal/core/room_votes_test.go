@@ -0,0 +1,80 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAddSkipVote(t *testing.T) {
+	r, _ := newTestRoom(t)
+	listeners := []int64{1, 2, 3}
+
+	if votes, added, passed := r.AddSkipVote(1, listeners, 2); votes != 1 || !added || passed {
+		t.Fatalf("first vote = %d, %v, %v; want 1, true, false", votes, added, passed)
+	}
+	if votes, added, passed := r.AddSkipVote(1, listeners, 2); votes != 1 || added || passed {
+		t.Fatalf("repeated vote = %d, %v, %v; want 1, false, false", votes, added, passed)
+	}
+
+	// user 1 left the voice chat, so their vote no longer counts
+	if votes, added, passed := r.AddSkipVote(2, listeners[1:], 2); votes != 1 || !added || passed {
+		t.Fatalf("vote after leave = %d, %v, %v; want 1, true, false", votes, added, passed)
+	}
+	if votes, added, passed := r.AddSkipVote(3, listeners[1:], 2); votes != 2 || !added || !passed {
+		t.Fatalf("deciding vote = %d, %v, %v; want 2, true, true", votes, added, passed)
+	}
+
+	// the votes were consumed by the pass
+	if votes, _, passed := r.AddSkipVote(2, listeners, 2); votes != 1 || passed {
+		t.Fatalf("vote after pass = %d, %v; want 1, false", votes, passed)
+	}
+}
+
+func TestAddSkipVotePassesOnce(t *testing.T) {
+	r, _ := newTestRoom(t)
+
+	const voters = 50
+	listeners := make([]int64, voters)
+	for i := range listeners {
+		listeners[i] = int64(i + 1)
+	}
+
+	var passes atomic.Int32
+	var wg sync.WaitGroup
+	for _, id := range listeners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, passed := r.AddSkipVote(id, listeners, voters/2); passed {
+				passes.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// half of the voters pass the first skip, the other half the second
+	if got := passes.Load(); got != 2 {
+		t.Fatalf("skip passed %d times, want 2", got)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package database
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// apiTokenPrefix marks YukkiMusic API tokens so they are easy to spot in
+// configs and logs.
+const apiTokenPrefix = "yk_"
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken generates a new API token for chatID and replaces the
+// previous one. Only its hash is stored, the token is returned once.
+func CreateAPIToken(chatID int64) (string, error) {
+	settings, err := getChatSettings(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := apiTokenPrefix + hex.EncodeToString(buf)
+
+	if old := settings.APIToken; old != "" {
+		dbCache.Delete("apitoken_" + old)
+	}
+	settings.APIToken = hashAPIToken(token)
+	if err := updateChatSettings(settings); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeAPIToken removes the API token of chatID, if any.
+func RevokeAPIToken(chatID int64) error {
+	settings, err := getChatSettings(chatID)
+	if err != nil || settings.APIToken == "" {
+		return err
+	}
+
+	dbCache.Delete("apitoken_" + settings.APIToken)
+	settings.APIToken = ""
+	return updateChatSettings(settings)
+}
+
+func HasAPIToken(chatID int64) (bool, error) {
+	settings, err := getChatSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	return settings.APIToken != "", nil
+}
+
+// GetChatIDFromAPIToken returns the chat an API token was issued for.
+func GetChatIDFromAPIToken(token string) (int64, error) {
+	hash := hashAPIToken(token)
+	cacheKey := "apitoken_" + hash
+	if cached, found := dbCache.Get(cacheKey); found {
+		if chatID, ok := cached.(int64); ok {
+			return chatID, nil
+		}
+	}
+
+	var found []*ChatSettings
+	err := store.Find(collChatSettings, map[string]any{"api_token": hash}, &found)
+	if err != nil {
+		return 0, err
+	}
+	if len(found) == 0 {
+		return 0, fmt.Errorf("unknown API token")
+	}
+
+	dbCache.Set(cacheKey, found[0].ChatID)
+	return found[0].ChatID, nil
+}
@@ -0,0 +1,118 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"main/internal/utils"
+)
+
+// setStore merges every Put into the stored document like a Mongo $set
+// update, so fields missing from the encoded document keep their old value.
+type setStore struct {
+	*fileStore
+}
+
+func (s setStore) Put(coll string, id, doc any) error {
+	merged := bson.M{}
+	if err := s.fileStore.Get(coll, id, &merged); err != nil && err != ErrNotFound {
+		return err
+	}
+
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	maps.Copy(merged, fields)
+	return s.fileStore.Put(coll, id, merged)
+}
+
+// useTestStore points the package at a fresh merging store and an empty
+// cache for the duration of the test.
+func useTestStore(t *testing.T) {
+	t.Helper()
+	fs, _ := newTestFileStore(t)
+	oldStore, oldCache := store, dbCache
+	store, dbCache = setStore{fs}, utils.NewCache[string, any](time.Minute)
+	t.Cleanup(func() {
+		fs.Close()
+		store, dbCache = oldStore, oldCache
+	})
+}
+
+func TestRevokedAPITokenNoLongerAuthenticates(t *testing.T) {
+	useTestStore(t)
+	const chatID = int64(-1001234567890)
+
+	token, err := CreateAPIToken(chatID)
+	if err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+	if got, err := GetChatIDFromAPIToken(token); err != nil || got != chatID {
+		t.Fatalf("GetChatIDFromAPIToken = %d, %v; want %d", got, err, chatID)
+	}
+
+	if err := RevokeAPIToken(chatID); err != nil {
+		t.Fatalf("RevokeAPIToken: %v", err)
+	}
+	// forget cached lookups so the store is asked, like after a restart
+	dbCache = utils.NewCache[string, any](time.Minute)
+
+	if got, err := GetChatIDFromAPIToken(token); err == nil {
+		t.Fatalf("revoked token still authenticates for chat %d", got)
+	}
+	if has, err := HasAPIToken(chatID); err != nil || has {
+		t.Fatalf("HasAPIToken = %v, %v; want false", has, err)
+	}
+}
+
+func TestNewAPITokenReplacesOld(t *testing.T) {
+	useTestStore(t)
+	const chatID = int64(-1001234567890)
+
+	old, err := CreateAPIToken(chatID)
+	if err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+	if _, err := GetChatIDFromAPIToken(old); err != nil {
+		t.Fatalf("GetChatIDFromAPIToken(old): %v", err)
+	}
+	token, err := CreateAPIToken(chatID)
+	if err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+
+	if _, err := GetChatIDFromAPIToken(old); err == nil {
+		t.Fatal("old token still authenticates")
+	}
+	if got, err := GetChatIDFromAPIToken(token); err != nil || got != chatID {
+		t.Fatalf("GetChatIDFromAPIToken = %d, %v; want %d", got, err, chatID)
+	}
+}
@@ -21,13 +21,9 @@
 package database
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"sync"
-	"time"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 var (
@@ -95,38 +91,19 @@ func RebalanceAssistantIndexes(assistantCount int) error {
 		return fmt.Errorf("assistantCount must be positive")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-
-	cursor, err := chatSettingsColl.Find(ctx, bson.M{})
-	if err != nil {
+	var all []*ChatSettings
+	if err := store.Find(collChatSettings, nil, &all); err != nil {
 		logger.Error(
 			"Failed to fetch chat settings for rebalance: " + err.Error(),
 		)
 		return err
 	}
-	defer cursor.Close(ctx)
 
-	var all []*ChatSettings
 	original := make(map[int64]int)
-
-	for cursor.Next(ctx) {
-		var s ChatSettings
-		if err := cursor.Decode(&s); err != nil {
-			logger.Error(
-				"Failed to decode chat setting during rebalance: " + err.Error(),
-			)
-			return err
-		}
-		all = append(all, &s)
+	for _, s := range all {
 		original[s.ChatID] = s.AssistantIndex
 	}
 
-	if err := cursor.Err(); err != nil {
-		logger.Error("Rebalance cursor error: " + err.Error())
-		return err
-	}
-
 	total := len(all)
 	if total == 0 {
 		logger.Debug("Rebalance: no chats found")
@@ -20,12 +20,6 @@
 */
 package database
 
-import (
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
-)
-
 // TODO: reflect deepequal checked removed so handle caching of same opt in high-level
 
 type UsersChats struct {
@@ -61,12 +55,9 @@ func getBotState() (*BotState, error) {
 			return state, nil
 		}
 	}
-	ctx, cancel := mongoCtx()
-	defer cancel()
-
 	var state BotState
-	err := settingsColl.FindOne(ctx, bson.M{"_id": "global"}).Decode(&state)
-	if err == mongo.ErrNoDocuments {
+	err := store.Get(collBotSettings, "global", &state)
+	if err == ErrNotFound {
 		dbCache.Set(cacheKey, defaultBotState)
 		return defaultBotState, nil
 	} else if err != nil {
@@ -79,16 +70,7 @@ func getBotState() (*BotState, error) {
 }
 
 func updateBotState(newState *BotState) error {
-	ctx, cancel := mongoCtx()
-	defer cancel()
-
-	opts := options.UpdateOne().SetUpsert(true)
-	_, err := settingsColl.UpdateOne(
-		ctx,
-		bson.M{"_id": "global"},
-		bson.M{"$set": newState},
-		opts,
-	)
+	err := store.Put(collBotSettings, "global", newState)
 	if err != nil {
 		logger.ErrorF("Failed to update bot state: %v", err)
 		return err
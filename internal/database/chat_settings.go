@@ -22,10 +22,6 @@ package database
 
 import (
 	"strconv"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // TODO: reflect deepequal checked removed so handle caching of same opt in high-level
@@ -34,12 +30,20 @@ type RTMPConfig struct {
 	RtmpKey string `bson:"rtmp_key"`
 }
 type ChatSettings struct {
-	ChatID         int64      `bson:"_id"`
-	CPlayID        int64      `bson:"cplay_id"`
-	AuthUsers      []int64    `bson:"auth_users"`
-	Language       string     `bson:"language"`
-	RTMPConfig     RTMPConfig `bson:"rtmp_config"`
-	AssistantIndex int        `bson:"ass_index,omitempty"`
+	ChatID          int64      `bson:"_id"`
+	CPlayID         int64      `bson:"cplay_id"`
+	AuthUsers       []int64    `bson:"auth_users"`
+	Language        string     `bson:"language"`
+	RTMPConfig      RTMPConfig `bson:"rtmp_config"`
+	AssistantIndex  int        `bson:"ass_index,omitempty"`
+	Autoplay        bool       `bson:"autoplay"`
+	VoteSkipPercent int        `bson:"vote_skip_percent"`
+	FairQueue       bool       `bson:"fair_queue"`
+	UserQueueLimit  int        `bson:"user_queue_limit"`
+	Crossfade       int        `bson:"crossfade"`
+	Volume          int        `bson:"volume,omitempty"`
+	APIToken        string     `bson:"api_token"`
+	SearchMode      bool       `bson:"search_mode"`
 }
 
 func defaultChatSettings(chatID int64) *ChatSettings {
@@ -50,9 +54,6 @@ func defaultChatSettings(chatID int64) *ChatSettings {
 }
 
 func getChatSettings(chatID int64) (*ChatSettings, error) {
-	ctx, cancel := mongoCtx()
-	defer cancel()
-
 	cacheKey := "chat_settings_" + strconv.FormatInt(chatID, 10)
 	if cached, found := dbCache.Get(cacheKey); found {
 		if settings, ok := cached.(*ChatSettings); ok {
@@ -61,9 +62,8 @@ func getChatSettings(chatID int64) (*ChatSettings, error) {
 	}
 
 	var settings ChatSettings
-	err := chatSettingsColl.FindOne(ctx, bson.M{"_id": chatID}).
-		Decode(&settings)
-	if err == mongo.ErrNoDocuments {
+	err := store.Get(collChatSettings, chatID, &settings)
+	if err == ErrNotFound {
 		def := defaultChatSettings(chatID)
 		dbCache.Set(cacheKey, def)
 		return def, nil
@@ -85,16 +85,8 @@ func getChatSettings(chatID int64) (*ChatSettings, error) {
 
 func updateChatSettings(newSettings *ChatSettings) error {
 	cacheKey := "chat_settings_" + strconv.FormatInt(newSettings.ChatID, 10)
-	ctx, cancel := mongoCtx()
-	defer cancel()
 
-	opts := options.UpdateOne().SetUpsert(true)
-	_, err := chatSettingsColl.UpdateOne(
-		ctx,
-		bson.M{"_id": newSettings.ChatID},
-		bson.M{"$set": newSettings},
-		opts,
-	)
+	err := store.Put(collChatSettings, newSettings.ChatID, newSettings)
 	if err != nil {
 		logger.Error(
 			"Failed to update chat settings for chat " + strconv.FormatInt(
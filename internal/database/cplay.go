@@ -22,9 +22,6 @@ package database
 
 import (
 	"fmt"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 func GetCPlayID(chatID int64) (int64, error) {
@@ -60,6 +57,16 @@ func SetCPlayID(chatID, cplayID int64) error {
 	return err
 }
 
+// SettingsChatID returns the chat whose settings apply to the room of
+// roomID: the group it's linked to for a channel play room, roomID itself
+// otherwise.
+func SettingsChatID(roomID int64) int64 {
+	if chatID, err := GetChatIDFromCPlayID(roomID); err == nil && chatID != 0 {
+		return chatID
+	}
+	return roomID
+}
+
 func GetChatIDFromCPlayID(cplayID int64) (int64, error) {
 	cacheKey := fmt.Sprintf("cplayid_%d", cplayID)
 	if cached, found := dbCache.Get(cacheKey); found {
@@ -68,19 +75,15 @@ func GetChatIDFromCPlayID(cplayID int64) (int64, error) {
 		}
 	}
 
-	ctx, cancel := mongoCtx()
-	defer cancel()
-
-	var settings ChatSettings
-	err := chatSettingsColl.FindOne(ctx, bson.M{"cplay_id": cplayID}).
-		Decode(&settings)
+	var found []*ChatSettings
+	err := store.Find(collChatSettings, map[string]any{"cplay_id": cplayID}, &found)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return 0, fmt.Errorf("no chat found with cplayID %d", cplayID)
-		}
 		return 0, err
 	}
+	if len(found) == 0 {
+		return 0, fmt.Errorf("no chat found with cplayID %d", cplayID)
+	}
 
-	dbCache.Set(cacheKey, settings.ChatID)
-	return settings.ChatID, nil
+	dbCache.Set(cacheKey, found[0].ChatID)
+	return found[0].ChatID, nil
 }
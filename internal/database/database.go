@@ -24,45 +24,42 @@ import (
 	"time"
 
 	"github.com/Laky-64/gologging"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"main/internal/utils"
 )
 
 var (
-	client           *mongo.Client
-	database         *mongo.Database
-	settingsColl     *mongo.Collection
-	chatSettingsColl *mongo.Collection
-
 	logger  = gologging.GetLogger("Database")
 	dbCache = utils.NewCache[string, any](60 * time.Minute)
 )
 
-func Init(mongoURL string) func() {
-	var err error
-	logger.Debug("Initializing MongoDB...")
-	client, err = mongo.Connect(options.Client().ApplyURI(mongoURL))
-	if err != nil {
-		logger.Fatal("Failed to connect to MongoDB: %v", err)
-	}
-
-	logger.Debug("Successfully connected to MongoDB.")
-
-	database = client.Database("queen")
-	settingsColl = database.Collection("bot_settings")
-	chatSettingsColl = database.Collection("chat_settings")
+// Init opens the storage backend. MongoDB is used when mongoURL is set,
+// otherwise everything is kept in the JSON file at filePath.
+func Init(mongoURL, filePath string) func() {
+	if mongoURL != "" {
+		logger.Debug("Initializing MongoDB...")
+		s, err := newMongoStore(mongoURL)
+		if err != nil {
+			logger.Fatal("Failed to connect to MongoDB: %v", err)
+		}
+		logger.Debug("Successfully connected to MongoDB.")
 
-	go migrateData(mongoURL)
+		store = s
+		go migrateData(s.client)
+	} else {
+		logger.Debug("Initializing file storage at " + filePath + "...")
+		s, err := newFileStore(filePath)
+		if err != nil {
+			logger.Fatal("Failed to open file storage: %v", err)
+		}
+		store = s
+	}
 
 	return func() {
-		ctx, cancel := mongoCtx()
-		defer cancel()
-		if err := client.Disconnect(ctx); err != nil {
-			logger.Error("Error while disconnecting MongoDB: %v", err)
+		if err := store.Close(); err != nil {
+			logger.Error("Error while closing database: %v", err)
 		} else {
-			logger.Info("MongoDB disconnected successfully")
+			logger.Info("Database closed successfully")
 		}
 	}
 }
@@ -0,0 +1,56 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package database
+
+func GetFairQueue(chatID int64) (bool, error) {
+	settings, err := getChatSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	return settings.FairQueue, nil
+}
+
+func SetFairQueue(chatID int64, value bool) error {
+	settings, err := getChatSettings(chatID)
+	if err != nil || settings.FairQueue == value {
+		return err
+	}
+	settings.FairQueue = value
+	return updateChatSettings(settings)
+}
+
+// GetUserQueueLimit returns how many tracks a single user may have
+// queued at once, 0 meaning no limit.
+func GetUserQueueLimit(chatID int64) (int, error) {
+	settings, err := getChatSettings(chatID)
+	if err != nil {
+		return 0, err
+	}
+	return settings.UserQueueLimit, nil
+}
+
+func SetUserQueueLimit(chatID int64, limit int) error {
+	settings, err := getChatSettings(chatID)
+	if err != nil || settings.UserQueueLimit == limit {
+		return err
+	}
+	settings.UserQueueLimit = limit
+	return updateChatSettings(settings)
+}
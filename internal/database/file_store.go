@@ -0,0 +1,250 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// fileFlushDelay batches writes so frequent updates don't rewrite
+// the file every time.
+const fileFlushDelay = time.Second
+
+// fileStore keeps every collection in memory and saves them as a single
+// extended JSON file. It's meant for small deployments where running a
+// MongoDB server isn't worth it.
+type fileStore struct {
+	path    string
+	writeMu sync.Mutex // serializes file writes
+
+	mu         sync.RWMutex
+	data       map[string]map[string]bson.Raw
+	flushTimer *time.Timer
+}
+
+func newFileStore(path string) (*fileStore, error) {
+	s := &fileStore{
+		path: path,
+		data: make(map[string]map[string]bson.Raw),
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(b) > 0 {
+		if err := bson.UnmarshalExtJSON(b, false, &s.data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *fileStore) Get(coll string, id, out any) error {
+	s.mu.RLock()
+	raw, ok := s.data[coll][docKey(id)]
+	s.mu.RUnlock()
+
+	if !ok {
+		return ErrNotFound
+	}
+	return bson.Unmarshal(raw, out)
+}
+
+func (s *fileStore) Put(coll string, id, doc any) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data[coll] == nil {
+		s.data[coll] = make(map[string]bson.Raw)
+	}
+	s.data[coll][docKey(id)] = raw
+	s.scheduleFlush()
+	return nil
+}
+
+func (s *fileStore) Delete(coll string, id any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := docKey(id)
+	if _, ok := s.data[coll][key]; !ok {
+		return nil
+	}
+	delete(s.data[coll], key)
+	s.scheduleFlush()
+	return nil
+}
+
+func (s *fileStore) Find(coll string, filter map[string]any, out any) error {
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Pointer || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("out must be a pointer to a slice, got %T", out)
+	}
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.data[coll]))
+	for k := range s.data[coll] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	docs := make([]bson.Raw, 0, len(keys))
+	for _, k := range keys {
+		docs = append(docs, s.data[coll][k])
+	}
+	s.mu.RUnlock()
+
+	result := reflect.MakeSlice(slice.Type(), 0, len(docs))
+	for _, raw := range docs {
+		if ok, err := matchFilter(raw, filter); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if elemType.Kind() == reflect.Pointer {
+			v := reflect.New(elemType.Elem())
+			if err := bson.Unmarshal(raw, v.Interface()); err != nil {
+				return err
+			}
+			result = reflect.Append(result, v)
+		} else {
+			v := reflect.New(elemType)
+			if err := bson.Unmarshal(raw, v.Interface()); err != nil {
+				return err
+			}
+			result = reflect.Append(result, v.Elem())
+		}
+	}
+
+	slice.Set(result)
+	return nil
+}
+
+func (s *fileStore) Close() error {
+	s.mu.Lock()
+	if s.flushTimer != nil {
+		s.flushTimer.Stop()
+		s.flushTimer = nil
+	}
+	s.mu.Unlock()
+	return s.flush()
+}
+
+// scheduleFlush must be called with s.mu held.
+func (s *fileStore) scheduleFlush() {
+	if s.flushTimer != nil {
+		return
+	}
+	s.flushTimer = time.AfterFunc(fileFlushDelay, func() {
+		s.mu.Lock()
+		s.flushTimer = nil
+		s.mu.Unlock()
+
+		if err := s.flush(); err != nil {
+			logger.ErrorF("Failed to write %s: %v", s.path, err)
+		}
+	})
+}
+
+func (s *fileStore) flush() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	b, err := bson.MarshalExtJSON(s.data, false, false)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	// write to a temp file first so a crash can't leave a truncated file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func matchFilter(raw bson.Raw, filter map[string]any) (bool, error) {
+	if len(filter) == 0 {
+		return true, nil
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return false, err
+	}
+
+	for k, want := range filter {
+		if !valuesEqual(doc[k], want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// valuesEqual compares a decoded bson value with a filter value,
+// treating all integer widths as equal.
+func valuesEqual(a, b any) bool {
+	ai, aok := toInt64(a)
+	bi, bok := toInt64(b)
+	if aok && bok {
+		return ai == bi
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
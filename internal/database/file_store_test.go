@@ -0,0 +1,248 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	state "main/internal/core/models"
+)
+
+func newTestFileStore(t *testing.T) (*fileStore, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "db.json")
+	s, err := newFileStore(path)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	return s, path
+}
+
+// reopen closes s, which flushes it, and loads the file again.
+func reopen(t *testing.T, s *fileStore, path string) *fileStore {
+	t.Helper()
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	s, err := newFileStore(path)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	return s
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	track := &state.Track{
+		ID:          "dQw4w9WgXcQ",
+		Title:       "Never Gonna Give You Up",
+		Duration:    213,
+		Artwork:     "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+		URL:         "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		Requester:   "<a href=\"tg://user?id=42\">Rick</a>",
+		RequesterID: 42,
+		Video:       true,
+		Source:      "YouTube",
+		Channel:     "Rick Astley",
+	}
+
+	tests := []struct {
+		name string
+		coll string
+		id   any
+		doc  any
+		out  func() any
+	}{
+		{
+			name: "chat settings",
+			coll: collChatSettings,
+			id:   int64(-1001234567890),
+			doc: &ChatSettings{
+				ChatID:          -1001234567890,
+				CPlayID:         -1009876543210,
+				AuthUsers:       []int64{1, 2, 3},
+				Language:        "en",
+				RTMPConfig:      RTMPConfig{RtmpURL: "rtmps://dc4.rtmp.t.me/s/", RtmpKey: "key"},
+				AssistantIndex:  2,
+				Autoplay:        true,
+				VoteSkipPercent: 60,
+				FairQueue:       true,
+				UserQueueLimit:  5,
+				Crossfade:       4,
+				Volume:          80,
+				APIToken:        "abc",
+				SearchMode:      true,
+			},
+			out: func() any { return &ChatSettings{} },
+		},
+		{
+			name: "room snapshot",
+			coll: collRoomSnapshots,
+			id:   int64(-1001234567890),
+			doc: &state.RoomSnapshot{
+				ChatID:    -1001234567890,
+				Track:     track,
+				Position:  97,
+				Queue:     []*state.Track{track, {ID: "x", Title: "Next", Duration: 60, Source: "SoundCloud"}},
+				Speed:     1.25,
+				Loop:      2,
+				Shuffle:   true,
+				CPlay:     true,
+				UpdatedAt: 1760000000,
+				Effects:   []string{"bassboost"},
+				EQ:        []float64{1.5, 0, -2, 0, 0, 0, 0, 0, 3, 4.5},
+				Volume:    150,
+			},
+			out: func() any { return &state.RoomSnapshot{} },
+		},
+		{
+			name: "schedule",
+			coll: collSchedules,
+			id:   "-1001234567890:1",
+			doc: &Schedule{
+				ID:        "-1001234567890:1",
+				Num:       1,
+				ChatID:    -1001234567890,
+				CPlay:     true,
+				Playlist:  "morning",
+				Force:     true,
+				Days:      []int{1, 3, 5},
+				Minute:    7*60 + 30,
+				CreatedBy: 42,
+				CreatedAt: 1760000000,
+			},
+			out: func() any { return &Schedule{} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, path := newTestFileStore(t)
+			if err := s.Put(tt.coll, tt.id, tt.doc); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			s = reopen(t, s, path)
+			defer s.Close()
+
+			got := tt.out()
+			if err := s.Get(tt.coll, tt.id, got); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.doc) {
+				t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", got, tt.doc)
+			}
+		})
+	}
+}
+
+func TestFileStoreGetDelete(t *testing.T) {
+	s, path := newTestFileStore(t)
+
+	var out Schedule
+	if err := s.Get(collSchedules, "missing", &out); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get of a missing document: got %v, want ErrNotFound", err)
+	}
+
+	doc := &Schedule{ID: "1:1", Num: 1, ChatID: 1, Query: "lofi"}
+	if err := s.Put(collSchedules, doc.ID, doc); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Delete(collSchedules, doc.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(collSchedules, doc.ID); err != nil {
+		t.Fatalf("Delete of a missing document: %v", err)
+	}
+
+	s = reopen(t, s, path)
+	defer s.Close()
+	if err := s.Get(collSchedules, doc.ID, &out); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStoreFind(t *testing.T) {
+	s, _ := newTestFileStore(t)
+	defer s.Close()
+
+	docs := []*Schedule{
+		{ID: "-100:1", Num: 1, ChatID: -100, Query: "a", Force: true},
+		{ID: "-100:2", Num: 2, ChatID: -100, Query: "b"},
+		{ID: "-200:1", Num: 1, ChatID: -200, Query: "c", Force: true},
+	}
+	for _, d := range docs {
+		if err := s.Put(collSchedules, d.ID, d); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		coll   string
+		filter map[string]any
+		want   []string // IDs, in key order
+	}{
+		{"nil filter", collSchedules, nil, []string{"-100:1", "-100:2", "-200:1"}},
+		{"int64 field", collSchedules, map[string]any{"chat_id": int64(-100)}, []string{"-100:1", "-100:2"}},
+		{"int matches int64", collSchedules, map[string]any{"chat_id": -200}, []string{"-200:1"}},
+		{"int field", collSchedules, map[string]any{"num": 1}, []string{"-100:1", "-200:1"}},
+		{"string field", collSchedules, map[string]any{"query": "b"}, []string{"-100:2"}},
+		{"bool field", collSchedules, map[string]any{"force": true}, []string{"-100:1", "-200:1"}},
+		{"several fields", collSchedules, map[string]any{"chat_id": int64(-100), "num": 2}, []string{"-100:2"}},
+		{"omitted field", collSchedules, map[string]any{"playlist": "x"}, nil},
+		{"no match", collSchedules, map[string]any{"chat_id": int64(-300)}, nil},
+		{"empty collection", collPlaylists, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ptrs []*Schedule
+			if err := s.Find(tt.coll, tt.filter, &ptrs); err != nil {
+				t.Fatalf("Find into []*Schedule: %v", err)
+			}
+			var vals []Schedule
+			if err := s.Find(tt.coll, tt.filter, &vals); err != nil {
+				t.Fatalf("Find into []Schedule: %v", err)
+			}
+
+			var gotPtrs, gotVals []string
+			for _, d := range ptrs {
+				gotPtrs = append(gotPtrs, d.ID)
+			}
+			for _, d := range vals {
+				gotVals = append(gotVals, d.ID)
+			}
+			if !reflect.DeepEqual(gotPtrs, tt.want) {
+				t.Errorf("[]*Schedule: got %v, want %v", gotPtrs, tt.want)
+			}
+			if !reflect.DeepEqual(gotVals, tt.want) {
+				t.Errorf("[]Schedule: got %v, want %v", gotVals, tt.want)
+			}
+		})
+	}
+
+	var notSlice Schedule
+	if err := s.Find(collSchedules, nil, &notSlice); err == nil {
+		t.Error("Find into a non-slice: expected an error")
+	}
+}
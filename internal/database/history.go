@@ -0,0 +1,138 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"strconv"
+	"sync"
+
+	state "main/internal/core/models"
+)
+
+const (
+	chatHistoryLimit = 100
+	userHistoryLimit = 50
+)
+
+// playHistory holds the most recent entries of a chat or a user,
+// newest first.
+type playHistory struct {
+	ID      int64                 `bson:"_id"`
+	Entries []*state.HistoryEntry `bson:"entries"`
+}
+
+var historyMu sync.Mutex
+
+func getHistory(coll string, id int64) (*playHistory, error) {
+	cacheKey := coll + "_" + strconv.FormatInt(id, 10)
+	if cached, found := dbCache.Get(cacheKey); found {
+		if h, ok := cached.(*playHistory); ok {
+			return h, nil
+		}
+	}
+
+	var h playHistory
+	err := store.Get(coll, id, &h)
+	if err == ErrNotFound {
+		h = playHistory{ID: id, Entries: []*state.HistoryEntry{}}
+	} else if err != nil {
+		logger.ErrorF("Failed to get %s for %d: %v", coll, id, err)
+		return nil, err
+	}
+
+	dbCache.Set(cacheKey, &h)
+	return &h, nil
+}
+
+func pushHistory(coll string, id int64, e *state.HistoryEntry, limit int) error {
+	h, err := getHistory(coll, id)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*state.HistoryEntry, 0, limit)
+	entries = append(entries, e)
+	entries = append(entries, h.Entries...)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	updated := &playHistory{ID: id, Entries: entries}
+	if err := store.Put(coll, id, updated); err != nil {
+		logger.ErrorF("Failed to update %s for %d: %v", coll, id, err)
+		return err
+	}
+
+	dbCache.Set(coll+"_"+strconv.FormatInt(id, 10), updated)
+	return nil
+}
+
+// AddHistory records a played track for its chat and, when known,
+// for the user who requested it.
+func AddHistory(e *state.HistoryEntry) error {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	if err := pushHistory(collHistory, e.ChatID, e, chatHistoryLimit); err != nil {
+		return err
+	}
+	if e.RequesterID != 0 {
+		return pushHistory(collUserHistory, e.RequesterID, e, userHistoryLimit)
+	}
+	return nil
+}
+
+func GetChatHistory(chatID int64) ([]*state.HistoryEntry, error) {
+	h, err := getHistory(collHistory, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return h.Entries, nil
+}
+
+func GetUserHistory(userID int64) ([]*state.HistoryEntry, error) {
+	h, err := getHistory(collUserHistory, userID)
+	if err != nil {
+		return nil, err
+	}
+	return h.Entries, nil
+}
+
+// GetHistoryEntry looks up a single entry by its ID in the history of
+// a chat or a user.
+func GetHistoryEntry(ownerID, entryID int64, user bool) (*state.HistoryEntry, error) {
+	coll := collHistory
+	if user {
+		coll = collUserHistory
+	}
+
+	h, err := getHistory(coll, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range h.Entries {
+		if e.ID == entryID {
+			return e, nil
+		}
+	}
+	return nil, ErrNotFound
+}
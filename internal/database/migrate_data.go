@@ -50,7 +50,7 @@ type (
 	}
 )
 
-func migrateData(mongoURI string) {
+func migrateData(client *mongo.Client) {
 	logger.Info("Checking for old database to migrate...")
 
 	oldDB := client.Database(oldDBName)
@@ -0,0 +1,102 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoStore struct {
+	client *mongo.Client
+	db     *mongo.Database
+}
+
+func newMongoStore(uri string) (*mongoStore, error) {
+	client, err := mongo.Connect(options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	return &mongoStore{client: client, db: client.Database("queen")}, nil
+}
+
+func (s *mongoStore) Get(coll string, id, out any) error {
+	ctx, cancel := mongoCtx()
+	defer cancel()
+
+	err := s.db.Collection(coll).FindOne(ctx, bson.M{"_id": id}).Decode(out)
+	if err == mongo.ErrNoDocuments {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Put replaces the whole document like the file store does, so fields
+// left out of doc (omitempty) don't keep their old values.
+func (s *mongoStore) Put(coll string, id, doc any) error {
+	ctx, cancel := mongoCtx()
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.db.Collection(coll).ReplaceOne(
+		ctx,
+		bson.M{"_id": id},
+		doc,
+		opts,
+	)
+	return err
+}
+
+func (s *mongoStore) Delete(coll string, id any) error {
+	ctx, cancel := mongoCtx()
+	defer cancel()
+
+	_, err := s.db.Collection(coll).DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (s *mongoStore) Find(coll string, filter map[string]any, out any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	f := bson.M{}
+	for k, v := range filter {
+		f[k] = v
+	}
+
+	cursor, err := s.db.Collection(coll).Find(ctx, f)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, out)
+}
+
+func (s *mongoStore) Close() error {
+	ctx, cancel := mongoCtx()
+	defer cancel()
+	return s.client.Disconnect(ctx)
+}
@@ -0,0 +1,96 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+// Playlist is a named list of tracks owned by a user, or by a chat when
+// Shared is set.
+type Playlist struct {
+	ID        string         `bson:"_id"` // "<owner_id>:<lowercased name>"
+	OwnerID   int64          `bson:"owner_id"`
+	Shared    bool           `bson:"shared"`
+	Name      string         `bson:"name"`
+	Tracks    []*state.Track `bson:"tracks"`
+	CreatedBy int64          `bson:"created_by"`
+	UpdatedAt int64          `bson:"updated_at"`
+}
+
+func playlistID(ownerID int64, name string) string {
+	return strconv.FormatInt(ownerID, 10) + ":" + strings.ToLower(name)
+}
+
+// GetPlaylists returns every playlist of a user or chat, sorted by name.
+func GetPlaylists(ownerID int64) ([]*Playlist, error) {
+	var lists []*Playlist
+	err := store.Find(collPlaylists, map[string]any{"owner_id": ownerID}, &lists)
+	if err != nil {
+		logger.ErrorF("Failed to list playlists of %d: %v", ownerID, err)
+		return nil, err
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return strings.ToLower(lists[i].Name) < strings.ToLower(lists[j].Name)
+	})
+	return lists, nil
+}
+
+// GetPlaylist looks a playlist up by name, case-insensitively.
+// It returns ErrNotFound when there is none.
+func GetPlaylist(ownerID int64, name string) (*Playlist, error) {
+	var p Playlist
+	if err := store.Get(collPlaylists, playlistID(ownerID, name), &p); err != nil {
+		if err != ErrNotFound {
+			logger.ErrorF("Failed to get playlist %q of %d: %v", name, ownerID, err)
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func SavePlaylist(p *Playlist) error {
+	p.ID = playlistID(p.OwnerID, p.Name)
+	p.UpdatedAt = time.Now().Unix()
+	if p.Tracks == nil {
+		p.Tracks = []*state.Track{}
+	}
+
+	if err := store.Put(collPlaylists, p.ID, p); err != nil {
+		logger.ErrorF("Failed to save playlist %q of %d: %v", p.Name, p.OwnerID, err)
+		return err
+	}
+	return nil
+}
+
+func DeletePlaylist(ownerID int64, name string) error {
+	if err := store.Delete(collPlaylists, playlistID(ownerID, name)); err != nil {
+		logger.ErrorF("Failed to delete playlist %q of %d: %v", name, ownerID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"strconv"
+	"time"
+)
+
+// PodcastResume is where a user stopped listening to a podcast episode.
+type PodcastResume struct {
+	ID        string `bson:"_id"` // "<user_id>:<episode_id>"
+	UserID    int64  `bson:"user_id"`
+	EpisodeID string `bson:"episode_id"`
+	Position  int    `bson:"position"` // seconds
+	UpdatedAt int64  `bson:"updated_at"`
+}
+
+// GetPodcastResume returns the saved position of userID in an episode, 0
+// when there is none.
+func GetPodcastResume(userID int64, episodeID string) (int, error) {
+	var p PodcastResume
+	if err := store.Get(collPodcastResume, podcastResumeID(userID, episodeID), &p); err != nil {
+		if err == ErrNotFound {
+			return 0, nil
+		}
+		logger.ErrorF("Failed to get podcast position of %d: %v", userID, err)
+		return 0, err
+	}
+	return p.Position, nil
+}
+
+func SetPodcastResume(userID int64, episodeID string, position int) error {
+	p := &PodcastResume{
+		ID:        podcastResumeID(userID, episodeID),
+		UserID:    userID,
+		EpisodeID: episodeID,
+		Position:  position,
+		UpdatedAt: time.Now().Unix(),
+	}
+	if err := store.Put(collPodcastResume, p.ID, p); err != nil {
+		logger.ErrorF("Failed to save podcast position of %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func DeletePodcastResume(userID int64, episodeID string) error {
+	err := store.Delete(collPodcastResume, podcastResumeID(userID, episodeID))
+	if err != nil && err != ErrNotFound {
+		logger.ErrorF("Failed to delete podcast position of %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func podcastResumeID(userID int64, episodeID string) string {
+	return strconv.FormatInt(userID, 10) + ":" + episodeID
+}
@@ -0,0 +1,50 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	state "main/internal/core/models"
+)
+
+func SaveRoomSnapshot(s *state.RoomSnapshot) error {
+	err := store.Put(collRoomSnapshots, s.ChatID, s)
+	if err != nil {
+		logger.ErrorF("Failed to save room snapshot for chat %d: %v", s.ChatID, err)
+	}
+	return err
+}
+
+func DeleteRoomSnapshot(chatID int64) error {
+	err := store.Delete(collRoomSnapshots, chatID)
+	if err != nil {
+		logger.ErrorF("Failed to delete room snapshot for chat %d: %v", chatID, err)
+	}
+	return err
+}
+
+func GetRoomSnapshots() ([]*state.RoomSnapshot, error) {
+	var snaps []*state.RoomSnapshot
+	if err := store.Find(collRoomSnapshots, nil, &snaps); err != nil {
+		logger.ErrorF("Failed to fetch room snapshots: %v", err)
+		return nil, err
+	}
+	return snaps, nil
+}
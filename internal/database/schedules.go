@@ -0,0 +1,128 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Schedule is a track, search or playlist that is played in a chat at a
+// set time, once or on a weekly pattern.
+type Schedule struct {
+	ID     string `bson:"_id"` // "<chat_id>:<num>"
+	Num    int    `bson:"num"` // shown to users, unique within the chat
+	ChatID int64  `bson:"chat_id"`
+	CPlay  bool   `bson:"cplay,omitempty"`
+
+	Query    string `bson:"query,omitempty"`
+	Playlist string `bson:"playlist,omitempty"` // played instead of Query when set
+	Force    bool   `bson:"force,omitempty"`
+
+	At     int64 `bson:"at,omitempty"`   // unix time of a one-off schedule
+	Days   []int `bson:"days,omitempty"` // time.Weekday values of a recurring one
+	Minute int   `bson:"minute"`         // minute of the day for recurring ones
+
+	CreatedBy int64 `bson:"created_by"`
+	CreatedAt int64 `bson:"created_at"`
+}
+
+// scheduleMu serializes AddSchedule so concurrent adds don't take the
+// same number.
+var scheduleMu sync.Mutex
+
+func (s *Schedule) Recurring() bool {
+	return len(s.Days) > 0
+}
+
+// GetSchedules returns the schedules of a chat ordered by number.
+func GetSchedules(chatID int64) ([]*Schedule, error) {
+	var list []*Schedule
+	err := store.Find(collSchedules, map[string]any{"chat_id": chatID}, &list)
+	if err != nil {
+		logger.ErrorF("Failed to list schedules of %d: %v", chatID, err)
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Num < list[j].Num })
+	return list, nil
+}
+
+// GetAllSchedules returns the schedules of every chat.
+func GetAllSchedules() ([]*Schedule, error) {
+	var list []*Schedule
+	if err := store.Find(collSchedules, nil, &list); err != nil {
+		logger.ErrorF("Failed to fetch schedules: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetSchedule returns ErrNotFound when the chat has no schedule with
+// that number.
+func GetSchedule(chatID int64, num int) (*Schedule, error) {
+	var s Schedule
+	if err := store.Get(collSchedules, scheduleID(chatID, num), &s); err != nil {
+		if err != ErrNotFound {
+			logger.ErrorF("Failed to get schedule %d of %d: %v", num, chatID, err)
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// AddSchedule numbers s after the chat's existing schedules and saves it.
+func AddSchedule(s *Schedule) error {
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+
+	list, err := GetSchedules(s.ChatID)
+	if err != nil {
+		return err
+	}
+
+	s.Num = 1
+	if len(list) > 0 {
+		s.Num = list[len(list)-1].Num + 1
+	}
+	s.ID = scheduleID(s.ChatID, s.Num)
+	s.CreatedAt = time.Now().Unix()
+
+	if err := store.Put(collSchedules, s.ID, s); err != nil {
+		logger.ErrorF("Failed to save schedule %d of %d: %v", s.Num, s.ChatID, err)
+		return err
+	}
+	return nil
+}
+
+func DeleteSchedule(chatID int64, num int) error {
+	if err := store.Delete(collSchedules, scheduleID(chatID, num)); err != nil {
+		logger.ErrorF("Failed to delete schedule %d of %d: %v", num, chatID, err)
+		return err
+	}
+	return nil
+}
+
+func scheduleID(chatID int64, num int) string {
+	return strconv.FormatInt(chatID, 10) + ":" + strconv.Itoa(num)
+}
@@ -0,0 +1,57 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAddScheduleConcurrentNumbers(t *testing.T) {
+	useTestStore(t)
+	const chatID = int64(-1001234567890)
+	const n = 20
+
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := AddSchedule(&Schedule{ChatID: chatID, Query: "lofi"}); err != nil {
+				t.Errorf("AddSchedule: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	list, err := GetSchedules(chatID)
+	if err != nil {
+		t.Fatalf("GetSchedules: %v", err)
+	}
+	if len(list) != n {
+		t.Fatalf("got %d schedules, want %d", len(list), n)
+	}
+	for i, s := range list {
+		if s.Num != i+1 {
+			t.Fatalf("schedule %d has number %d, want %d", i, s.Num, i+1)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"testing"
+	"time"
+
+	"main/internal/utils"
+)
+
+func TestSearchModeOffIsSaved(t *testing.T) {
+	useTestStore(t)
+	const chatID = int64(-1001234567890)
+
+	if err := SetSearchMode(chatID, true); err != nil {
+		t.Fatalf("SetSearchMode(true): %v", err)
+	}
+	if err := SetSearchMode(chatID, false); err != nil {
+		t.Fatalf("SetSearchMode(false): %v", err)
+	}
+	// forget the cached settings so they are read back from the store
+	dbCache = utils.NewCache[string, any](time.Minute)
+
+	if on, err := GetSearchMode(chatID); err != nil || on {
+		t.Fatalf("GetSearchMode = %v, %v; want false", on, err)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package database
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by Store.Get when no document has the given id.
+var ErrNotFound = errors.New("document not found")
+
+const (
+	collBotSettings   = "bot_settings"
+	collChatSettings  = "chat_settings"
+	collRoomSnapshots = "room_snapshots"
+	collHistory       = "history"
+	collUserHistory   = "user_history"
+	collPlaylists     = "playlists"
+	collSchedules     = "schedules"
+	collPodcastResume = "podcast_resume"
+)
+
+// Store is the persistence backend used by this package. Documents are
+// plain structs with bson tags, grouped in named collections and keyed
+// by their "_id" field.
+type Store interface {
+	// Get decodes the document with the given id into out.
+	Get(coll string, id any, out any) error
+	// Put inserts the document or replaces the existing one.
+	Put(coll string, id any, doc any) error
+	Delete(coll string, id any) error
+	// Find decodes every document whose fields equal filter into out,
+	// which must be a pointer to a slice. A nil filter matches all.
+	Find(coll string, filter map[string]any, out any) error
+	Close() error
+}
+
+var store Store
+
+func docKey(id any) string {
+	return fmt.Sprint(id)
+}
@@ -0,0 +1,42 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package database
+
+// DefaultVoteSkipPercent is used until a chat sets its own threshold.
+const DefaultVoteSkipPercent = 50
+
+// GetVoteSkipPercent returns the share of voice chat listeners, in
+// percent, that has to vote before a track is skipped.
+func GetVoteSkipPercent(chatID int64) (int, error) {
+	settings, err := getChatSettings(chatID)
+	if err != nil || settings.VoteSkipPercent == 0 {
+		return DefaultVoteSkipPercent, err
+	}
+	return settings.VoteSkipPercent, nil
+}
+
+func SetVoteSkipPercent(chatID int64, percent int) error {
+	settings, err := getChatSettings(chatID)
+	if err != nil || settings.VoteSkipPercent == percent {
+		return err
+	}
+	settings.VoteSkipPercent = percent
+	return updateChatSettings(settings)
+}
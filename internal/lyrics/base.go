@@ -0,0 +1,144 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// ErrNotFound is returned when no provider has lyrics for a query.
+var ErrNotFound = errors.New("no lyrics found")
+
+type providerEntry struct {
+	provider state.LyricsProvider
+	priority int
+}
+
+var (
+	providers   []providerEntry
+	providersMu sync.RWMutex
+
+	cache = utils.NewCache[string, *state.Lyrics](6 * time.Hour)
+)
+
+// Register adds a lyrics provider with the given priority.
+// Higher priority = asked first
+func Register(priority int, p state.LyricsProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers = append(providers, providerEntry{provider: p, priority: priority})
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].priority > providers[j].priority
+	})
+}
+
+// Get asks the registered providers in order and returns the first lyrics
+// found. Synced lyrics from a later provider are preferred over plain ones.
+func Get(ctx context.Context, query string, duration int) (*state.Lyrics, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrNotFound
+	}
+
+	key := strings.ToLower(query) + "|" + utils.IntToStr(duration)
+	if l, ok := cache.Get(key); ok {
+		return l, nil
+	}
+
+	providersMu.RLock()
+	list := make([]state.LyricsProvider, len(providers))
+	for i, e := range providers {
+		list[i] = e.provider
+	}
+	providersMu.RUnlock()
+
+	var plain *state.Lyrics
+	for _, p := range list {
+		l, err := p.GetLyrics(ctx, query, duration)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if !errors.Is(err, ErrNotFound) {
+				gologging.DebugF("Lyrics provider %s failed: %v", p.Name(), err)
+			}
+			continue
+		}
+		if l == nil {
+			continue
+		}
+		if l.Source == "" {
+			l.Source = p.Name()
+		}
+		if len(l.Synced) > 0 {
+			cache.Set(key, l)
+			return l, nil
+		}
+		if plain == nil {
+			plain = l
+		}
+	}
+
+	if plain == nil {
+		return nil, ErrNotFound
+	}
+	cache.Set(key, plain)
+	return plain, nil
+}
+
+var (
+	bracketRe = regexp.MustCompile(`\s*[\(\[\{【][^\)\]\}】]*[\)\]\}】]`)
+	noiseRe   = regexp.MustCompile(`(?i)\b(official|music|lyrics?|lyrical|video|audio|hd|4k|mv|visualizer)\b`)
+	spacesRe  = regexp.MustCompile(`\s+`)
+)
+
+// CleanTitle turns a track title like "Artist - Song (Official Video)"
+// into a query lyrics providers can match.
+func CleanTitle(title string) string {
+	q := bracketRe.ReplaceAllString(title, "")
+	if i := strings.Index(q, "|"); i > 0 {
+		q = q[:i]
+	}
+	q = noiseRe.ReplaceAllString(q, "")
+	q = strings.NewReplacer(" - ", " ", " – ", " ", "ft.", "", "feat.", "").Replace(q)
+	q = spacesRe.ReplaceAllString(q, " ")
+	return strings.TrimSpace(q)
+}
+
+// LineAt returns the index of the synced line being sung at pos, -1 before
+// the first line.
+func LineAt(lines []state.LyricLine, pos time.Duration) int {
+	return sort.Search(len(lines), func(i int) bool {
+		return lines[i].At > pos
+	}) - 1
+}
@@ -0,0 +1,125 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+)
+
+// LocalProvider serves lyrics from .lrc and .txt files in a directory,
+// named after the song, e.g. "Artist - Title.lrc". It's asked before the
+// online providers, so it can also fix lyrics they get wrong.
+type LocalProvider struct {
+	Dir string
+}
+
+func init() {
+	Register(100, &LocalProvider{Dir: config.LyricsDir})
+}
+
+func (p *LocalProvider) Name() string {
+	return "local"
+}
+
+func (p *LocalProvider) GetLyrics(
+	_ context.Context,
+	query string,
+	_ int,
+) (*state.Lyrics, error) {
+	if p.Dir == "" {
+		return nil, ErrNotFound
+	}
+
+	entries, err := os.ReadDir(p.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	want := normalizeName(query)
+	if want == "" {
+		return nil, ErrNotFound
+	}
+
+	// an exact name wins, otherwise a file whose name is part of the query
+	// (titles often carry extra words) or the other way round
+	var match string
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".lrc" && ext != ".txt") {
+			continue
+		}
+		name := normalizeName(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())))
+		if name == "" {
+			continue
+		}
+		if name == want {
+			match = e.Name()
+			break
+		}
+		if match == "" && len(name) >= 4 &&
+			(strings.Contains(want, name) || strings.Contains(name, want)) {
+			match = e.Name()
+		}
+	}
+	if match == "" {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.Dir, match))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &state.Lyrics{
+		Title: strings.TrimSuffix(match, filepath.Ext(match)),
+	}
+	if strings.EqualFold(filepath.Ext(match), ".lrc") {
+		l.Synced, l.Plain = ParseLRC(string(data))
+	} else {
+		l.Plain = strings.TrimSpace(string(data))
+	}
+	if l.Plain == "" {
+		return nil, ErrNotFound
+	}
+	return l, nil
+}
+
+// normalizeName keeps only lower case letters and digits, so file names
+// match queries regardless of punctuation.
+func normalizeName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newLocalProvider(t *testing.T, files map[string]string) *LocalProvider {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "Artist - Folder.lrc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return &LocalProvider{Dir: dir}
+}
+
+func TestLocalProvider(t *testing.T) {
+	p := newLocalProvider(t, map[string]string{
+		"Artist - Song.lrc":      "[ti:Song]\n[00:01.00]Hello\n[00:02.00]World\n",
+		"Artist - Song Live.txt": "live words\n",
+		"Other Band - Tune.TXT":  "  tune words  \n",
+		"Empty.lrc":              "[ti:Empty]\n",
+		"notes.md":               "not lyrics",
+	})
+
+	tests := []struct {
+		name      string
+		query     string
+		wantTitle string
+		wantPlain string
+		wantLines int
+		wantErr   error
+	}{
+		// "Artist - Song Live.txt" is listed first and matches partially
+		{"exact wins over partial", "artist - song", "Artist - Song", "Hello\nWorld", 2, nil},
+		{"punctuation ignored", "Artist: Song!", "Artist - Song", "Hello\nWorld", 2, nil},
+		{"exact txt", "Artist - Song (Live)", "Artist - Song Live", "live words", 0, nil},
+		{"file name in query", "Other Band - Tune (Official Video)", "Other Band - Tune", "tune words", 0, nil},
+		{"query in file name", "band tune", "Other Band - Tune", "tune words", 0, nil},
+		{"no match", "Unknown - Nothing", "", "", 0, ErrNotFound},
+		{"empty lyrics", "empty", "", "", 0, ErrNotFound},
+		{"other extensions skipped", "notes", "", "", 0, ErrNotFound},
+		{"directories skipped", "Artist - Folder", "", "", 0, ErrNotFound},
+		{"empty query", "!!!", "", "", 0, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := p.GetLyrics(context.Background(), tt.query, 0)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetLyrics(%q) error = %v, want %v", tt.query, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLyrics(%q): %v", tt.query, err)
+			}
+			if l.Title != tt.wantTitle || l.Plain != tt.wantPlain || len(l.Synced) != tt.wantLines {
+				t.Fatalf("GetLyrics(%q) = %q, %q, %d lines; want %q, %q, %d lines",
+					tt.query, l.Title, l.Plain, len(l.Synced), tt.wantTitle, tt.wantPlain, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestLocalProviderMissingDir(t *testing.T) {
+	for _, dir := range []string{"", filepath.Join(t.TempDir(), "missing")} {
+		p := &LocalProvider{Dir: dir}
+		if _, err := p.GetLyrics(context.Background(), "Artist - Song", 0); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetLyrics with Dir %q error = %v, want ErrNotFound", dir, err)
+		}
+	}
+}
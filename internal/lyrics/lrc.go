@@ -0,0 +1,112 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+var (
+	lrcTimeRe   = regexp.MustCompile(`\[(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcOffsetRe = regexp.MustCompile(`(?i)^\[offset:\s*([+-]?\d+)\]`)
+	lrcTagRe    = regexp.MustCompile(`^\[[a-zA-Z#]+:.*\]$`)
+)
+
+// ParseLRC reads LRC lyrics into lines sorted by time. Lines may carry
+// several timestamps, and an [offset:ms] tag shifts them all. The second
+// value is the text without timestamps or tags.
+func ParseLRC(text string) ([]state.LyricLine, string) {
+	var (
+		lines  []state.LyricLine
+		plain  []string
+		offset time.Duration
+	)
+
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		raw = strings.TrimSpace(raw)
+
+		if m := lrcOffsetRe.FindStringSubmatch(raw); m != nil {
+			ms, _ := strconv.Atoi(m[1])
+			// a positive offset makes lyrics come sooner
+			offset = -time.Duration(ms) * time.Millisecond
+			continue
+		}
+
+		stamps := lrcTimeRe.FindAllStringSubmatchIndex(raw, -1)
+		if len(stamps) == 0 {
+			if !lrcTagRe.MatchString(raw) {
+				plain = append(plain, raw)
+			}
+			continue
+		}
+
+		// timestamps lead the line, the text follows the last one
+		end := 0
+		for _, s := range stamps {
+			if s[0] != end {
+				break
+			}
+			end = s[1]
+		}
+		words := strings.TrimSpace(raw[end:])
+		plain = append(plain, words)
+
+		for _, s := range stamps {
+			if s[1] > end {
+				break
+			}
+			lines = append(lines, state.LyricLine{
+				At:   lrcStamp(raw, s),
+				Text: words,
+			})
+		}
+	}
+
+	// the offset tag may come after some lines, it still applies to them
+	for i := range lines {
+		lines[i].At += offset
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].At < lines[j].At })
+	return lines, strings.TrimSpace(strings.Join(plain, "\n"))
+}
+
+func lrcStamp(raw string, s []int) time.Duration {
+	mins, _ := strconv.Atoi(raw[s[2]:s[3]])
+	secs, _ := strconv.Atoi(raw[s[4]:s[5]])
+	d := time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+
+	if s[6] >= 0 {
+		frac := raw[s[6]:s[7]]
+		n, _ := strconv.Atoi(frac)
+		// .5 is half a second, .50 and .500 as well
+		for i := len(frac); i < 3; i++ {
+			n *= 10
+		}
+		d += time.Duration(n) * time.Millisecond
+	}
+	return d
+}
@@ -0,0 +1,160 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+func ms(n int) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantLines []state.LyricLine
+		wantPlain string
+	}{
+		{
+			name: "tags and plain text",
+			text: "[ti:Song]\n[ar:Artist]\n[00:01.00]First\n[00:02.50]Second\n",
+			wantLines: []state.LyricLine{
+				{At: ms(1000), Text: "First"},
+				{At: ms(2500), Text: "Second"},
+			},
+			wantPlain: "First\nSecond",
+		},
+		{
+			name: "fraction padding",
+			text: "[00:01.5]a\n[00:02.05]b\n[00:03.005]c\n[00:04:25]d\n[01:02]e",
+			wantLines: []state.LyricLine{
+				{At: ms(1500), Text: "a"},
+				{At: ms(2050), Text: "b"},
+				{At: ms(3005), Text: "c"},
+				{At: ms(4250), Text: "d"},
+				{At: ms(62000), Text: "e"},
+			},
+			wantPlain: "a\nb\nc\nd\ne",
+		},
+		{
+			name: "several timestamps per line",
+			text: "[00:10.00][00:30.00]Chorus\n[00:20.00]Verse",
+			wantLines: []state.LyricLine{
+				{At: ms(10000), Text: "Chorus"},
+				{At: ms(20000), Text: "Verse"},
+				{At: ms(30000), Text: "Chorus"},
+			},
+			wantPlain: "Chorus\nVerse",
+		},
+		{
+			name: "timestamp inside the text is kept",
+			text: "[00:01.00]at [00:02.00] sharp",
+			wantLines: []state.LyricLine{
+				{At: ms(1000), Text: "at [00:02.00] sharp"},
+			},
+			wantPlain: "at [00:02.00] sharp",
+		},
+		{
+			name: "positive offset comes sooner",
+			text: "[offset:+500]\n[00:02.00]a\n[00:03.00]b",
+			wantLines: []state.LyricLine{
+				{At: ms(1500), Text: "a"},
+				{At: ms(2500), Text: "b"},
+			},
+			wantPlain: "a\nb",
+		},
+		{
+			name: "negative offset comes later",
+			text: "[offset:-250]\n[00:02.00]a",
+			wantLines: []state.LyricLine{
+				{At: ms(2250), Text: "a"},
+			},
+			wantPlain: "a",
+		},
+		{
+			name: "offset after the lines",
+			text: "[00:02.00]a\n[00:03.00]b\n[offset:1000]",
+			wantLines: []state.LyricLine{
+				{At: ms(1000), Text: "a"},
+				{At: ms(2000), Text: "b"},
+			},
+			wantPlain: "a\nb",
+		},
+		{
+			name: "crlf and untimed lines",
+			text: "Intro\r\n[00:01.00]a\r\n\r\n[00:02.00]b\r\n",
+			wantLines: []state.LyricLine{
+				{At: ms(1000), Text: "a"},
+				{At: ms(2000), Text: "b"},
+			},
+			wantPlain: "Intro\na\n\nb",
+		},
+		{
+			name:      "plain lyrics",
+			text:      "just\nwords",
+			wantLines: nil,
+			wantPlain: "just\nwords",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, plain := ParseLRC(tt.text)
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %+v, want %+v", lines, tt.wantLines)
+			}
+			if plain != tt.wantPlain {
+				t.Errorf("plain = %q, want %q", plain, tt.wantPlain)
+			}
+		})
+	}
+}
+
+func TestLineAt(t *testing.T) {
+	lines := []state.LyricLine{
+		{At: ms(1000), Text: "a"},
+		{At: ms(2000), Text: "b"},
+		{At: ms(3000), Text: "c"},
+	}
+
+	tests := []struct {
+		pos  time.Duration
+		want int
+	}{
+		{0, -1},
+		{ms(999), -1},
+		{ms(1000), 0},
+		{ms(2500), 1},
+		{ms(3000), 2},
+		{time.Hour, 2},
+	}
+	for _, tt := range tests {
+		if got := LineAt(lines, tt.pos); got != tt.want {
+			t.Errorf("LineAt(%s) = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package lyrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+)
+
+// lrclibMaxDrift is how far, in seconds, a result's duration may be off
+// from the track and still count as the same recording.
+const lrclibMaxDrift = 3
+
+// LrclibProvider searches the LRCLIB database, which has synced lyrics for
+// many songs. https://lrclib.net/docs
+type LrclibProvider struct {
+	BaseURL string
+}
+
+type lrclibResult struct {
+	TrackName    string  `json:"trackName"`
+	ArtistName   string  `json:"artistName"`
+	Duration     float64 `json:"duration"`
+	Instrumental bool    `json:"instrumental"`
+	PlainLyrics  string  `json:"plainLyrics"`
+	SyncedLyrics string  `json:"syncedLyrics"`
+}
+
+func init() {
+	Register(50, &LrclibProvider{BaseURL: config.LrclibURL})
+}
+
+func (p *LrclibProvider) Name() string {
+	return "LRCLIB"
+}
+
+func (p *LrclibProvider) GetLyrics(
+	ctx context.Context,
+	query string,
+	duration int,
+) (*state.Lyrics, error) {
+	if p.BaseURL == "" {
+		return nil, ErrNotFound
+	}
+
+	client := resty.New().
+		SetTimeout(15*time.Second).
+		SetHeader("User-Agent", "YukkiMusic (https://github.com/TheTeamVivek/YukkiMusic)")
+	defer client.Close()
+
+	var results []lrclibResult
+	resp, err := client.R().
+		SetContext(ctx).
+		SetQueryParam("q", query).
+		SetResult(&results).
+		Get(strings.TrimRight(p.BaseURL, "/") + "/api/search")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("LRCLIB returned %s", resp.Status())
+	}
+
+	best := pickLrclibResult(results, duration)
+	if best == nil {
+		return nil, ErrNotFound
+	}
+
+	l := &state.Lyrics{
+		Title:  best.TrackName,
+		Artist: best.ArtistName,
+		Plain:  strings.TrimSpace(best.PlainLyrics),
+	}
+	if best.SyncedLyrics != "" {
+		var plain string
+		l.Synced, plain = ParseLRC(best.SyncedLyrics)
+		if l.Plain == "" {
+			l.Plain = plain
+		}
+	}
+	if l.Plain == "" {
+		return nil, ErrNotFound
+	}
+	return l, nil
+}
+
+// pickLrclibResult prefers results close to the track's duration, then
+// synced over plain lyrics, keeping LRCLIB's own ranking otherwise.
+func pickLrclibResult(results []lrclibResult, duration int) *lrclibResult {
+	var (
+		best      *lrclibResult
+		bestScore = -1
+	)
+	for i := range results {
+		r := &results[i]
+		if r.Instrumental || (r.PlainLyrics == "" && r.SyncedLyrics == "") {
+			continue
+		}
+
+		score := 0
+		if duration > 0 {
+			if math.Abs(r.Duration-float64(duration)) <= lrclibMaxDrift {
+				score += 2
+			} else if r.SyncedLyrics != "" {
+				// timings of another recording would be off
+				r.SyncedLyrics = ""
+			}
+		}
+		if r.SyncedLyrics != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,794 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/config"
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/server"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/apitoken"] = `<i>Control playback in this chat from other apps over the HTTP API.</i>
+
+<u>Usage:</u>
+<b>/apitoken</b> — Show whether this chat has a token
+<b>/apitoken new</b> — Create a token, replacing the old one
+<b>/apitoken revoke</b> — Revoke the token
+
+<b>⚙️ Behavior:</b>
+• The token is sent to you in private, start the bot there first
+• It works for this chat and its linked channel only
+• Send it as <code>Authorization: Bearer &lt;token&gt;</code>
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> can use this
+
+<b>⚠️ Notes:</b>
+• Requires <code>HTTP_ADDR</code> to be set by the bot owner`
+}
+
+const (
+	// apiMaxBody caps the size of API request bodies.
+	apiMaxBody = 64 << 10
+	// apiEventPing is how often an idle event stream gets a keep-alive.
+	apiEventPing = 25 * time.Second
+	// apiTicketTTL is how long an event stream ticket can be redeemed.
+	apiTicketTTL = 30 * time.Second
+)
+
+var (
+	apiTicketsMu sync.Mutex
+	apiTickets   = map[string]apiTicket{}
+)
+
+type (
+	apiTrack struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Duration    int    `json:"duration"`
+		URL         string `json:"url"`
+		Artwork     string `json:"artwork,omitempty"`
+		Source      string `json:"source"`
+		Video       bool   `json:"video"`
+		IsLive      bool   `json:"is_live"`
+		RequesterID int64  `json:"requester_id,omitempty"`
+	}
+
+	apiRoom struct {
+		ChatID   int64       `json:"chat_id"`
+		Active   bool        `json:"active"`
+		Paused   bool        `json:"paused"`
+		Muted    bool        `json:"muted"`
+		Position int         `json:"position"`
+		Speed    float64     `json:"speed"`
+		Volume   int         `json:"volume"`
+		Loop     int         `json:"loop"`
+		Shuffle  bool        `json:"shuffle"`
+		Track    *apiTrack   `json:"track"`
+		Queue    []*apiTrack `json:"queue"`
+	}
+
+	apiEvent struct {
+		Type      core.EventType `json:"type"`
+		ChatID    int64          `json:"chat_id"`
+		Time      int64          `json:"time"`
+		Track     *apiTrack      `json:"track,omitempty"`
+		Position  int            `json:"position"`
+		Index     int            `json:"index,omitempty"`
+		Speed     float64        `json:"speed,omitempty"`
+		Paused    bool           `json:"paused"`
+		Reason    string         `json:"reason,omitempty"`
+		Assistant int64          `json:"assistant,omitempty"`
+	}
+
+	apiError struct {
+		Error string `json:"error"`
+	}
+
+	// apiTicket lets one event stream of chatID connect without the
+	// Authorization header, which EventSource can't send.
+	apiTicket struct {
+		chatID  int64
+		expires time.Time
+	}
+
+	// apiRoomHandler serves a request for the room at chatID, whose
+	// messages go to linkedChat (the chat the token belongs to).
+	apiRoomHandler func(
+		w http.ResponseWriter,
+		req *http.Request,
+		r *core.RoomState,
+		linkedChat int64,
+	)
+)
+
+func registerAPI() {
+	server.HandleFunc("GET /api/rooms", apiListRooms)
+	server.HandleFunc("GET /api/rooms/{chat}", apiRoomRoute(apiGetRoom, false))
+	server.HandleFunc("GET /api/rooms/{chat}/events", apiEvents)
+	server.HandleFunc("POST /api/rooms/{chat}/events/ticket", apiEventTicket)
+	server.HandleFunc("POST /api/rooms/{chat}/queue", apiRoomRoute(apiEnqueue, true))
+	server.HandleFunc("DELETE /api/rooms/{chat}/queue", apiRoomRoute(apiClearQueue, false))
+	server.HandleFunc("PATCH /api/rooms/{chat}/queue/{index}", apiRoomRoute(apiMoveQueued, false))
+	server.HandleFunc("DELETE /api/rooms/{chat}/queue/{index}", apiRoomRoute(apiRemoveQueued, false))
+	server.HandleFunc("POST /api/rooms/{chat}/skip", apiRoomRoute(apiSkip, false))
+	server.HandleFunc("POST /api/rooms/{chat}/pause", apiRoomRoute(apiPause, false))
+	server.HandleFunc("POST /api/rooms/{chat}/resume", apiRoomRoute(apiResume, false))
+	server.HandleFunc("POST /api/rooms/{chat}/seek", apiRoomRoute(apiSeek, false))
+	server.HandleFunc("POST /api/rooms/{chat}/speed", apiRoomRoute(apiSpeed, false))
+	server.HandleFunc("POST /api/rooms/{chat}/stop", apiRoomRoute(apiStop, false))
+}
+
+func apiTokenHandler(m *telegram.NewMessage) error {
+	chatID := m.ChannelID()
+
+	switch strings.ToLower(strings.TrimSpace(m.Args())) {
+	case "":
+		has, err := database.HasAPIToken(chatID)
+		if err != nil {
+			m.Reply(F(chatID, "apitoken_fetch_fail"))
+			return telegram.ErrEndGroup
+		}
+		key := utils.IfElse(has, "apitoken_status_on", "apitoken_status_off")
+		m.Reply(F(chatID, key, locales.Arg{
+			"cmd": getCommand(m),
+		}))
+
+	case "new", "create", "generate":
+		token, err := database.CreateAPIToken(chatID)
+		if err != nil {
+			m.Reply(F(chatID, "apitoken_update_fail"))
+			return telegram.ErrEndGroup
+		}
+
+		_, err = core.Bot.SendMessage(m.SenderID(), F(chatID, "apitoken_created", locales.Arg{
+			"chat":    html.EscapeString(m.Channel.Title),
+			"chat_id": chatID,
+			"token":   token,
+		}))
+		if err != nil {
+			// nobody else may see it, so don't keep a token we couldn't deliver
+			database.RevokeAPIToken(chatID)
+			m.Reply(F(chatID, "apitoken_pm_fail"))
+			return telegram.ErrEndGroup
+		}
+		m.Reply(F(chatID, "apitoken_sent", locales.Arg{
+			"user": utils.MentionHTML(m.Sender),
+		}))
+
+	case "revoke", "off", "delete":
+		if err := database.RevokeAPIToken(chatID); err != nil {
+			m.Reply(F(chatID, "apitoken_update_fail"))
+			return telegram.ErrEndGroup
+		}
+		m.Reply(F(chatID, "apitoken_revoked"))
+
+	default:
+		m.Reply(F(chatID, "apitoken_usage", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+	}
+	return telegram.ErrEndGroup
+}
+
+// apiChats authenticates req and returns the chat its token was issued for
+// together with every room ID the token may control.
+func apiChats(w http.ResponseWriter, req *http.Request) (int64, []int64, bool) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		writeAPIError(w, http.StatusUnauthorized, "missing bearer token")
+		return 0, nil, false
+	}
+
+	chatID, err := database.GetChatIDFromAPIToken(token)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "invalid token")
+		return 0, nil, false
+	}
+
+	chats := []int64{chatID}
+	if cplayID, err := database.GetCPlayID(chatID); err == nil && cplayID != 0 {
+		chats = append(chats, cplayID)
+	}
+	return chatID, chats, true
+}
+
+// apiChat returns the {chat} path value if the caller's token may control
+// it, along with the chat the token belongs to.
+func apiChat(w http.ResponseWriter, req *http.Request) (int64, int64, bool) {
+	linkedChat, chats, ok := apiChats(w, req)
+	if !ok {
+		return 0, 0, false
+	}
+
+	chatID, err := strconv.ParseInt(req.PathValue("chat"), 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid chat id")
+		return 0, 0, false
+	}
+	if !slices.Contains(chats, chatID) {
+		writeAPIError(w, http.StatusForbidden, "token is not valid for this chat")
+		return 0, 0, false
+	}
+	return chatID, linkedChat, true
+}
+
+// apiRoomRoute resolves the {chat} path value to its room. Rooms are only
+// created when create is set, otherwise an unknown room is answered with
+// an idle state, or 409 for actions.
+func apiRoomRoute(h apiRoomHandler, create bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		chatID, linkedChat, ok := apiChat(w, req)
+		if !ok {
+			return
+		}
+
+		var r *core.RoomState
+		if create {
+			ass, err := core.Assistants.ForChat(chatID)
+			if err != nil {
+				writeAPIError(w, http.StatusServiceUnavailable, err.Error())
+				return
+			}
+			r, _ = core.GetRoom(chatID, ass, true)
+			r.SetCPlay(chatID != linkedChat)
+		} else if r, ok = core.GetRoom(chatID, nil); !ok {
+			if req.Method == http.MethodGet {
+				server.WriteJSON(w, http.StatusOK, &apiRoom{ChatID: chatID, Queue: []*apiTrack{}})
+				return
+			}
+			writeAPIError(w, http.StatusConflict, "nothing is playing")
+			return
+		}
+
+		req.Body = http.MaxBytesReader(w, req.Body, apiMaxBody)
+		h(w, req, r, linkedChat)
+	}
+}
+
+func apiListRooms(w http.ResponseWriter, req *http.Request) {
+	_, chats, ok := apiChats(w, req)
+	if !ok {
+		return
+	}
+
+	rooms := make([]*apiRoom, 0, len(chats))
+	for _, chatID := range chats {
+		if r, ok := core.GetRoom(chatID, nil); ok {
+			rooms = append(rooms, newAPIRoom(r, false))
+		} else {
+			rooms = append(rooms, &apiRoom{ChatID: chatID, Queue: []*apiTrack{}})
+		}
+	}
+	server.WriteJSON(w, http.StatusOK, map[string]any{"rooms": rooms})
+}
+
+// apiEventTicket issues a one-time ticket for the event stream of {chat},
+// for clients that can't set headers.
+func apiEventTicket(w http.ResponseWriter, req *http.Request) {
+	chatID, _, ok := apiChat(w, req)
+	if !ok {
+		return
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "failed to create ticket")
+		return
+	}
+	ticket := hex.EncodeToString(buf)
+
+	now := time.Now()
+	apiTicketsMu.Lock()
+	for t, v := range apiTickets {
+		if now.After(v.expires) {
+			delete(apiTickets, t)
+		}
+	}
+	apiTickets[ticket] = apiTicket{chatID: chatID, expires: now.Add(apiTicketTTL)}
+	apiTicketsMu.Unlock()
+
+	server.WriteJSON(w, http.StatusOK, map[string]any{
+		"ticket":     ticket,
+		"expires_in": int(apiTicketTTL.Seconds()),
+	})
+}
+
+// redeemAPITicket consumes ticket and reports whether it was issued for
+// chatID and hasn't expired.
+func redeemAPITicket(ticket string, chatID int64) bool {
+	apiTicketsMu.Lock()
+	defer apiTicketsMu.Unlock()
+
+	t, ok := apiTickets[ticket]
+	delete(apiTickets, ticket)
+	return ok && t.chatID == chatID && time.Now().Before(t.expires)
+}
+
+// apiEventsChat authenticates an event stream by its bearer token or by a
+// ticket from apiEventTicket.
+func apiEventsChat(w http.ResponseWriter, req *http.Request) (int64, bool) {
+	ticket := req.URL.Query().Get("ticket")
+	if ticket == "" {
+		chatID, _, ok := apiChat(w, req)
+		return chatID, ok
+	}
+
+	chatID, err := strconv.ParseInt(req.PathValue("chat"), 10, 64)
+	if err != nil || !redeemAPITicket(ticket, chatID) {
+		writeAPIError(w, http.StatusUnauthorized, "invalid or expired ticket")
+		return 0, false
+	}
+	return chatID, true
+}
+
+// apiEvents streams the room events of a chat as server-sent events, named
+// after the event type.
+func apiEvents(w http.ResponseWriter, req *http.Request) {
+	chatID, ok := apiEventsChat(w, req)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	events, unsubscribe := core.Subscribe(chatID, 64)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ping := time.NewTicker(apiEventPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-ping.C:
+			io.WriteString(w, ": ping\n\n")
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(newAPIEvent(e))
+			if err != nil {
+				gologging.DebugF("[api] failed to encode event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+		}
+		flusher.Flush()
+	}
+}
+
+func apiGetRoom(w http.ResponseWriter, _ *http.Request, r *core.RoomState, _ int64) {
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, true))
+}
+
+// apiEnqueue resolves the query like /play does and hands the tracks to
+// playResolvedTracks in the background, the chat sees the usual messages.
+// Tracks are requested by requester_id when given, otherwise by the bot.
+func apiEnqueue(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	var body struct {
+		Query       string `json:"query"`
+		Video       bool   `json:"video"`
+		Force       bool   `json:"force"`
+		RequesterID int64  `json:"requester_id"`
+	}
+	if !decodeAPIBody(w, req, &body) {
+		return
+	}
+	body.Query = strings.TrimSpace(body.Query)
+	if body.Query == "" {
+		writeAPIError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	requester := core.BUser
+	if body.RequesterID != 0 {
+		user, err := core.Bot.GetUser(body.RequesterID)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "unknown requester")
+			return
+		}
+		requester = user
+	}
+
+	r.Parse()
+	if len(r.Queue()) >= config.QueueLimit {
+		writeAPIError(w, http.StatusConflict, fmt.Sprintf("queue limit of %d reached", config.QueueLimit))
+		return
+	}
+
+	replyMsg, err := core.Bot.SendMessage(linkedChat, F(linkedChat, "searching_query", locales.Arg{
+		"query": html.EscapeString(body.Query),
+	}))
+	if err != nil {
+		writeAPIError(w, http.StatusBadGateway, "failed to message the chat: "+err.Error())
+		return
+	}
+
+	tracks, err := platforms.GetTracksByQuery(body.Query, body.Video)
+	if err != nil || len(tracks) == 0 {
+		utils.EOR(replyMsg, F(linkedChat, "no_song_found"))
+		writeAPIError(w, http.StatusNotFound, "no tracks found")
+		return
+	}
+
+	resolved := make([]*apiTrack, len(tracks))
+	for i, t := range tracks {
+		resolved[i] = newAPITrack(t)
+	}
+
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				handlePanic(rec, replyMsg, true)
+			}
+		}()
+		playResolvedTracks(replyMsg, requester, replyMsg, r, tracks, body.Force)
+	}()
+
+	server.WriteJSON(w, http.StatusAccepted, map[string]any{"tracks": resolved})
+}
+
+func apiSkip(w http.ResponseWriter, _ *http.Request, r *core.RoomState, linkedChat int64) {
+	if !apiRequireActive(w, r) {
+		return
+	}
+
+	t, err := skipRoom(r, linkedChat)
+	if errors.Is(err, errQueueEnded) {
+		apiNotify(linkedChat, "skip_stopped", locales.Arg{})
+		server.WriteJSON(w, http.StatusOK, map[string]any{"stopped": true})
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	server.WriteJSON(w, http.StatusOK, map[string]any{"track": newAPITrack(t)})
+}
+
+func apiPause(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	var body struct {
+		// auto resume after this many seconds, same bounds as /pause
+		Seconds int `json:"seconds"`
+	}
+	if !decodeAPIBody(w, req, &body) || !apiRequireActive(w, r) {
+		return
+	}
+	if body.Seconds != 0 && (body.Seconds < 5 || body.Seconds > 3600) {
+		writeAPIError(w, http.StatusBadRequest, "seconds must be between 5 and 3600")
+		return
+	}
+	if r.IsPaused() {
+		writeAPIError(w, http.StatusConflict, "already paused")
+		return
+	}
+
+	var err error
+	if body.Seconds > 0 {
+		_, err = r.Pause(time.Duration(body.Seconds) * time.Second)
+	} else {
+		_, err = r.Pause()
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	autoResumeLine := ""
+	if body.Seconds > 0 {
+		autoResumeLine = F(linkedChat, "auto_resume_line", locales.Arg{
+			"seconds": body.Seconds,
+		})
+	}
+	apiNotify(linkedChat, "pause_success", locales.Arg{
+		"title":            html.EscapeString(utils.ShortTitle(r.Track().Title, 25)),
+		"position":         formatDuration(r.Position()),
+		"duration":         formatDuration(r.Track().Duration),
+		"auto_resume_line": autoResumeLine,
+	})
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, false))
+}
+
+func apiResume(w http.ResponseWriter, _ *http.Request, r *core.RoomState, linkedChat int64) {
+	if !apiRequireActive(w, r) {
+		return
+	}
+	if !r.IsPaused() {
+		writeAPIError(w, http.StatusConflict, "not paused")
+		return
+	}
+	if _, err := r.Resume(); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	speedLine := ""
+	if sp := r.GetSpeed(); sp != 1.0 {
+		speedLine = F(linkedChat, "speed_line", locales.Arg{
+			"speed": fmt.Sprintf("%.2f", sp),
+		})
+	}
+	apiNotify(linkedChat, "resume_success", locales.Arg{
+		"title":      html.EscapeString(utils.ShortTitle(r.Track().Title, 25)),
+		"position":   formatDuration(r.Position()),
+		"duration":   formatDuration(r.Track().Duration),
+		"speed_line": speedLine,
+	})
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, false))
+}
+
+func apiSeek(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	var body struct {
+		Position *int `json:"position"` // absolute, like /jump
+		Seconds  int  `json:"seconds"`  // relative, negative seeks back
+	}
+	if !decodeAPIBody(w, req, &body) || !apiRequireActive(w, r) {
+		return
+	}
+
+	r.Parse()
+	t := r.Track()
+	seconds := body.Seconds
+	if body.Position != nil {
+		if *body.Position < 0 {
+			writeAPIError(w, http.StatusBadRequest, "position must not be negative")
+			return
+		}
+		seconds = *body.Position - r.Position()
+	}
+	target := max(r.Position()+seconds, 0)
+	if t.Duration-target <= 10 {
+		writeAPIError(w, http.StatusBadRequest, "too close to the end of the track")
+		return
+	}
+
+	if err := r.Seek(seconds); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	apiNotify(linkedChat, "jump_success", locales.Arg{
+		"position": formatDuration(r.Position()),
+		"duration": formatDuration(t.Duration),
+	})
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, false))
+}
+
+func apiSpeed(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	var body struct {
+		Speed float64 `json:"speed"`
+	}
+	if !decodeAPIBody(w, req, &body) || !apiRequireActive(w, r) {
+		return
+	}
+	if body.Speed == 0 {
+		writeAPIError(w, http.StatusBadRequest, "speed is required")
+		return
+	}
+
+	if err := r.SetSpeed(body.Speed); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if body.Speed == 1.0 {
+		apiNotify(linkedChat, "speed_reset_success", locales.Arg{})
+	} else {
+		apiNotify(linkedChat, "speed_set", locales.Arg{
+			"speed": fmt.Sprintf("%.2f", body.Speed),
+		})
+	}
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, false))
+}
+
+func apiStop(w http.ResponseWriter, _ *http.Request, r *core.RoomState, linkedChat int64) {
+	if !apiRequireActive(w, r) {
+		return
+	}
+	r.Destroy()
+	apiNotify(linkedChat, "stopped", locales.Arg{})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiClearQueue(w http.ResponseWriter, _ *http.Request, r *core.RoomState, linkedChat int64) {
+	if !apiRequireActive(w, r) {
+		return
+	}
+	if len(r.Queue()) == 0 {
+		writeAPIError(w, http.StatusConflict, "queue is empty")
+		return
+	}
+	r.RemoveFromQueue(-1)
+	apiNotify(linkedChat, "clear_success", locales.Arg{})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiRemoveQueued(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	index, ok := apiQueueIndex(w, req, "index", r)
+	if !ok {
+		return
+	}
+	r.RemoveFromQueue(index - 1)
+	apiNotify(linkedChat, "remove_success", locales.Arg{
+		"index": index,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiMoveQueued(w http.ResponseWriter, req *http.Request, r *core.RoomState, linkedChat int64) {
+	var body struct {
+		To int `json:"to"`
+	}
+	if !decodeAPIBody(w, req, &body) {
+		return
+	}
+	from, ok := apiQueueIndex(w, req, "index", r)
+	if !ok {
+		return
+	}
+	if body.To <= 0 || body.To > len(r.Queue()) {
+		writeAPIError(w, http.StatusBadRequest, "to must be a position in the queue")
+		return
+	}
+
+	r.MoveInQueue(from-1, body.To-1)
+	apiNotify(linkedChat, "move_success", locales.Arg{
+		"from": from,
+		"to":   body.To,
+	})
+	server.WriteJSON(w, http.StatusOK, newAPIRoom(r, true))
+}
+
+// apiQueueIndex parses the 1-based queue position in the named path value,
+// the same numbering /queue shows.
+func apiQueueIndex(w http.ResponseWriter, req *http.Request, name string, r *core.RoomState) (int, bool) {
+	if !apiRequireActive(w, r) {
+		return 0, false
+	}
+	index, err := strconv.Atoi(req.PathValue(name))
+	if err != nil || index <= 0 || index > len(r.Queue()) {
+		writeAPIError(w, http.StatusNotFound, "no such queue position")
+		return 0, false
+	}
+	return index, true
+}
+
+func apiRequireActive(w http.ResponseWriter, r *core.RoomState) bool {
+	if !r.IsActiveChat() || r.Track() == nil {
+		writeAPIError(w, http.StatusConflict, "nothing is playing")
+		return false
+	}
+	return true
+}
+
+// apiNotify tells the chat about a change made over the API, with the
+// same message the matching command replies with.
+func apiNotify(chatID int64, key string, args locales.Arg) {
+	args["user"] = F(chatID, "api_user")
+	if _, err := core.Bot.SendMessage(chatID, F(chatID, key, args)); err != nil {
+		gologging.DebugF("[api] failed to notify %d: %v", chatID, err)
+	}
+}
+
+func decodeAPIBody(w http.ResponseWriter, req *http.Request, v any) bool {
+	err := json.NewDecoder(req.Body).Decode(v)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	server.WriteJSON(w, status, &apiError{Error: msg})
+}
+
+func newAPIRoom(r *core.RoomState, withQueue bool) *apiRoom {
+	r.Parse()
+	room := &apiRoom{
+		ChatID:   r.ChatID(),
+		Active:   r.IsActiveChat(),
+		Paused:   r.IsPaused(),
+		Muted:    r.IsMuted(),
+		Position: r.Position(),
+		Speed:    r.Speed(),
+		Volume:   r.Volume(),
+		Loop:     r.Loop(),
+		Shuffle:  r.Shuffle(),
+		Queue:    []*apiTrack{},
+	}
+	if t := r.Track(); t != nil && room.Active {
+		room.Track = newAPITrack(t)
+	}
+	if withQueue {
+		for _, t := range r.Queue() {
+			room.Queue = append(room.Queue, newAPITrack(t))
+		}
+	}
+	return room
+}
+
+func newAPIEvent(e core.Event) *apiEvent {
+	ev := &apiEvent{
+		Type:      e.Type,
+		ChatID:    e.ChatID,
+		Time:      e.Time,
+		Position:  e.Position,
+		Index:     e.Index,
+		Speed:     e.Speed,
+		Paused:    e.Paused,
+		Reason:    string(e.Reason),
+		Assistant: e.Assistant,
+	}
+	if e.Track != nil {
+		ev.Track = newAPITrack(e.Track)
+	}
+	return ev
+}
+
+func newAPITrack(t *state.Track) *apiTrack {
+	return &apiTrack{
+		ID:          t.ID,
+		Title:       t.Title,
+		Duration:    t.Duration,
+		URL:         t.URL,
+		Artwork:     t.Artwork,
+		Source:      string(t.Source),
+		Video:       t.Video,
+		IsLive:      t.IsLive,
+		RequesterID: t.RequesterID,
+	}
+}
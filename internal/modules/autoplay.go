@@ -0,0 +1,143 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strings"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+// autoplayHistoryDepth is how many recently played tracks autoplay
+// avoids repeating.
+const autoplayHistoryDepth = 30
+
+func init() {
+	helpTexts["/autoplay"] = `<i>Keep the music going when the queue runs dry.</i>
+
+<u>Usage:</u>
+<b>/autoplay</b> — Show current autoplay state
+<b>/autoplay on</b> — Enable autoplay
+<b>/autoplay off</b> — Disable autoplay
+
+<b>⚙️ Behavior:</b>
+• When the last track ends, a related track is played
+• Spotify tracks continue with the artist's top tracks
+• Other tracks continue with a YouTube search based on the last one
+• Recently played tracks are not repeated
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Tip:</b>
+Use <code>/stop</code> to end playback while autoplay is on.`
+}
+
+func autoplayHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+	arg := strings.ToLower(m.Args())
+
+	current, err := database.GetAutoplay(chatID)
+	if err != nil {
+		m.Reply(F(chatID, "autoplay_fetch_fail"))
+		return tg.ErrEndGroup
+	}
+
+	if arg == "" {
+		m.Reply(F(chatID, "autoplay_status", locales.Arg{
+			"state": F(chatID, utils.IfElse(current, "enabled", "disabled")),
+			"cmd":   getCommand(m) + utils.IfElse(current, " off", " on"),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	value, err := utils.ParseBool(arg)
+	if err != nil {
+		m.Reply(F(chatID, "invalid_bool"))
+		return tg.ErrEndGroup
+	}
+
+	status := F(chatID, utils.IfElse(value, "enabled", "disabled"))
+	if value == current {
+		m.Reply(F(chatID, "autoplay_already", locales.Arg{"state": status}))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetAutoplay(chatID, value); err != nil {
+		m.Reply(F(chatID, "autoplay_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "autoplay_updated", locales.Arg{"state": status}))
+	return tg.ErrEndGroup
+}
+
+// autoplayTrack picks a track related to the one that just ended, or
+// returns nil when autoplay is off or nothing new was found.
+func autoplayTrack(chatID int64, r *core.RoomState) *state.Track {
+	enabled, err := database.GetAutoplay(chatID)
+	if err != nil || !enabled {
+		return nil
+	}
+
+	last := r.Track()
+	if last == nil {
+		return nil
+	}
+
+	tracks, err := platforms.GetRelatedTracks(last)
+	if err != nil {
+		gologging.DebugF("Autoplay found nothing for %s: %v", last.URL, err)
+		return nil
+	}
+
+	playedIDs := map[string]bool{last.ID: true}
+	playedTitles := map[string]bool{strings.ToLower(last.Title): true}
+	if entries, err := database.GetChatHistory(r.ChatID()); err == nil {
+		for i, e := range entries {
+			if i >= autoplayHistoryDepth {
+				break
+			}
+			if e.Track != nil {
+				playedIDs[e.Track.ID] = true
+				playedTitles[strings.ToLower(e.Track.Title)] = true
+			}
+		}
+	}
+
+	for _, t := range tracks {
+		if playedIDs[t.ID] || playedTitles[strings.ToLower(t.Title)] {
+			continue
+		}
+		t.Requester = F(chatID, "autoplay_requester")
+		t.RequesterID = 0
+		return t
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ import (
 	"main/internal/core"
 	"main/internal/database"
 	"main/internal/locales"
+	"main/internal/server"
 )
 
 var (
@@ -43,6 +44,7 @@ var (
 	broadcastActive bool
 	broadcastCancel context.CancelFunc
 	broadcastCtx    context.Context
+	lastBroadcast   *BroadcastStats // running or last finished, for /metrics
 
 	defaultDelay = 1.5
 )
@@ -214,6 +216,10 @@ func broadcastHandler(m *tg.NewMessage) error {
 
 	broadcastCtx, broadcastCancel = context.WithCancel(context.Background())
 
+	broadcastMu.Lock()
+	lastBroadcast = stats
+	broadcastMu.Unlock()
+
 	progressMsg, err := m.Reply(F(chatID, "broadcast_initializing"),
 		&tg.SendOptions{
 			ReplyMarkup: core.GetBroadcastCancelKeyboard(chatID),
@@ -259,6 +265,26 @@ func broadcastHandler(m *tg.NewMessage) error {
 	return tg.ErrEndGroup
 }
 
+// broadcastProgress reports the running or last broadcast for /metrics.
+func broadcastProgress() server.Broadcast {
+	broadcastMu.Lock()
+	stats, active := lastBroadcast, broadcastActive
+	broadcastMu.Unlock()
+
+	if stats == nil {
+		return server.Broadcast{Active: active}
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return server.Broadcast{
+		Active: active,
+		Total:  stats.TotalChats + stats.TotalUsers,
+		Done:   stats.DoneChats + stats.DoneUsers,
+		Failed: len(stats.FailedChats) + len(stats.FailedUsers),
+	}
+}
+
 func parseBroadcastCommand(m *tg.NewMessage) (*BroadcastFlags, string, error) {
 	flags := &BroadcastFlags{
 		Delay: defaultDelay,
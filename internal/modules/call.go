@@ -28,6 +28,7 @@ import (
 	"github.com/amarnathcjd/gogram/telegram"
 
 	"main/internal/core"
+	state "main/internal/core/models"
 	"main/internal/database"
 	"main/internal/locales"
 	"main/internal/platforms"
@@ -56,22 +57,39 @@ func onStreamEndHandler(chatID int64) {
 		chatID = cid
 	}
 
-	if len(r.Queue()) == 0 && r.Loop() == 0 {
-		r.Destroy()
-		core.Bot.SendMessage(chatID, F(chatID, "stream_queue_finished"))
+	if r.CountStopAfter() {
+		r.SetEndReason(state.EndFinished)
+		r.StopScheduled()
 		return
 	}
 
-	t := r.NextTrack()
+	var t *state.Track
+	autoplay := false
+
+	if len(r.Queue()) == 0 && r.Loop() == 0 {
+		t = autoplayTrack(chatID, r)
+		if t == nil {
+			r.SetEndReason(state.EndFinished)
+			r.Destroy()
+			core.Bot.SendMessage(chatID, F(chatID, "stream_queue_finished"))
+			return
+		}
+		autoplay = true
+		// records the finished track and releases its file
+		r.NextTrack()
+	} else {
+		t = r.NextTrack()
+	}
+
 	mystic, err := core.Bot.SendMessage(
 		chatID,
-		F(chatID, "stream_downloading_next"),
+		F(chatID, utils.IfElse(autoplay, "autoplay_next", "stream_downloading_next")),
 	)
 	if err != nil {
 		gologging.ErrorF("[call.go] Failed to send msg: %v", err)
 	}
 
-	filePath, err := platforms.Download(context.Background(), t, mystic)
+	filePath, err := downloadNext(r, t, mystic)
 	if err != nil {
 		gologging.ErrorF("Download failed for %s: %v", t.URL, err)
 		utils.EOR(mystic, F(chatID, "stream_download_fail", locales.Arg{
@@ -80,7 +98,9 @@ func onStreamEndHandler(chatID int64) {
 		return
 	}
 
-	if err := r.Play(t, filePath); err != nil {
+	// the finished track is still set when autoplaying, so force
+	// playback instead of appending to the queue
+	if err := r.Play(t, filePath, autoplay); err != nil {
 		utils.EOR(mystic, F(chatID, "stream_play_fail"))
 		return
 	}
@@ -107,3 +127,16 @@ func onStreamEndHandler(chatID int64) {
 	mystic, _ = utils.EOR(mystic, msgText, opt)
 	r.SetMystic(mystic)
 }
+
+// downloadNext returns the file of t, the track that follows in r. The
+// copy prefetched by the room is used when there is one.
+func downloadNext(
+	r *core.RoomState,
+	t *state.Track,
+	mystic *telegram.NewMessage,
+) (string, error) {
+	if path, ok := r.Preloaded(context.Background(), t); ok {
+		return path, nil
+	}
+	return platforms.Download(context.Background(), t, mystic)
+}
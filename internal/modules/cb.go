@@ -21,7 +21,6 @@
 package modules
 
 import (
-	"context"
 	"fmt"
 	"html"
 	"strconv"
@@ -32,9 +31,9 @@ import (
 	tg "github.com/amarnathcjd/gogram/telegram"
 
 	"main/internal/core"
+	state "main/internal/core/models"
 	"main/internal/database"
 	"main/internal/locales"
-	"main/internal/platforms"
 	"main/internal/utils"
 )
 
@@ -42,13 +41,17 @@ import (
 type actionHandler func(*tg.CallbackQuery, *core.RoomState, int64) error
 
 var actionHandlers = map[string]actionHandler{
-	"pause":  handlePauseAction,
-	"resume": handleResumeAction,
-	"replay": handleReplayAction,
-	"skip":   handleSkipAction,
-	"stop":   handleStopAction,
-	"mute":   handleMuteAction,
-	"unmute": handleUnmuteAction,
+	"pause":   handlePauseAction,
+	"resume":  handleResumeAction,
+	"replay":  handleReplayAction,
+	"skip":    handleSkipAction,
+	"stop":    handleStopAction,
+	"mute":    handleMuteAction,
+	"unmute":  handleUnmuteAction,
+	"volup":   handleVolumeUpAction,
+	"voldown": handleVolumeDownAction,
+
+	"sleep_off": handleSleepOffAction,
 }
 
 func cancelHandler(cb *tg.CallbackQuery) error {
@@ -123,6 +126,11 @@ func roomHandle(cb *tg.CallbackQuery) error {
 		return tg.ErrEndGroup
 	}
 
+	// Anyone in the voice chat may vote
+	if action == "voteskip" {
+		return handleVoteSkipAction(cb, r, chatID)
+	}
+
 	// Check permissions
 	if !checkAdminOrAuth(cb, chatID, opt) {
 		return tg.ErrEndGroup
@@ -138,6 +146,11 @@ func roomHandle(cb *tg.CallbackQuery) error {
 		return handleSeekAction(cb, r, action, opt)
 	}
 
+	// Handle effect toggles from /effect
+	if strings.HasPrefix(action, "fx_") {
+		return handleEffectAction(cb, r, action, opt)
+	}
+
 	// Dispatch to handler
 	if handler, ok := actionHandlers[action]; ok {
 		return handler(cb, r, chatID)
@@ -340,6 +353,7 @@ func handleSkipAction(
 	opt := &tg.CallbackOptions{Alert: true}
 
 	gologging.InfoF("Callback → skip, chatID=%d", chatID)
+	r.SetEndReason(state.EndSkipped)
 
 	if len(r.Queue()) == 0 && r.Loop() == 0 {
 		r.Destroy()
@@ -357,7 +371,7 @@ func handleSkipAction(
 		gologging.ErrorF("Failed to send message: %v", err)
 	}
 
-	path, err := platforms.Download(context.Background(), t, mystic)
+	path, err := downloadNext(r, t, mystic)
 	if err != nil {
 		gologging.ErrorF("Download failed for %s: %v", t.URL, err)
 		utils.EOR(mystic, F(cb.ChannelID(), "stream_download_fail", locales.Arg{
@@ -59,6 +59,13 @@ var AllCommands = BotCommands{
 		{"play", "Play a song."},
 		{"queue", "Show the queue."},
 		{"position", "Show the current position of the song."},
+		{"history", "Show recently played songs."},
+		{"lyrics", "Show the lyrics of a song."},
+		{"radio", "Search and play an internet radio station."},
+		{"search", "Pick a song from the search results."},
+		{"lastplayed", "Show the last played song."},
+		{"voteskip", "Vote to skip the current song."},
+		{"playlist", "Manage and play saved playlists."},
 
 		{"reload", "Reload the admin cache."},
 		{"authlist", "List authorized users."},
@@ -74,8 +81,13 @@ var AllCommands = BotCommands{
 		},
 		{"fplay", "Force play a song."},
 		{"speed", "Set the speed of the song."},
+		{"effect", "Apply audio effects like bass boost."},
+		{"eq", "Adjust the equalizer."},
+		{"volume", "Set the stream volume."},
 		{"skip", "Skip the current song."},
 		{"pause", "Pause the current song."},
+		{"sleep", "Stop playback after a while."},
+		{"stopafter", "Stop playback after a number of songs."},
 		{"resume", "Resume the current song."},
 		{"replay", "Replay the current song."},
 		{"mute", "Mute the bot in the voice chat."},
@@ -87,10 +99,16 @@ var AllCommands = BotCommands{
 		{"remove", "Remove a song from the queue."},
 		{"move", "Move a song in the queue."},
 		{"shuffle", "Shuffle the queue."},
+		{"autoplay", "Play related songs when the queue ends."},
+		{"fairqueue", "Take turns between requesters."},
+		{"searchmode", "Let /play show the search results."},
+		{"crossfade", "Blend tracks into each other."},
+		{"schedule", "Play something at a set time."},
 		{"loop", "Loop the current song."},
 		{"end", "Stop the song."},
 		{"addauth", "Add a user to the authorized list."},
 		{"delauth", "Remove a user from the authorized list."},
+		{"apitoken", "Manage the HTTP API token of this chat."},
 		{"channelplay", "Set a channel as the play channel."},
 		{"cfplay", "Force play a song in the linked channel."},
 		{"cpause", "Pause the current song in the linked channel."},
@@ -116,6 +134,9 @@ var AllCommands = BotCommands{
 		{"cclear", "Clear the linked channel's queue."},
 		{"cmove", "Move a song in the linked channel's queue."},
 		{"cspeed", "Set the speed of the song in the linked channel."},
+		{"ceffect", "Apply audio effects in the linked channel."},
+		{"ceq", "Adjust the equalizer in the linked channel."},
+		{"cvolume", "Set the stream volume in the linked channel."},
 		{"creplay", "Replay the current song in the linked channel."},
 		{"cshuffle", "Shuffle the linked channel's queue."},
 		{"creload", "Reload the admin cache in the linked channel."},
@@ -0,0 +1,114 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strconv"
+	"strings"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/crossfade"] = `<i>Blend the end of each track into the start of the next one.</i>
+
+<u>Usage:</u>
+<b>/crossfade</b> — Show current crossfade duration
+<b>/crossfade &lt;seconds&gt;</b> — Overlap tracks by this many seconds (1-` + strconv.Itoa(core.MaxCrossfade) + `)
+<b>/crossfade off</b> — Disable crossfade
+
+<b>⚙️ Behavior:</b>
+• The next queued track is always downloaded while the current one plays
+• With crossfade on, the next track starts before the current one ends and both are faded into each other
+• Skipped into, looped, live and video tracks are not crossfaded
+• Also applies to the linked channel (/cplay)
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Examples:</b>
+<code>/crossfade 6</code>
+<code>/crossfade off</code>`
+}
+
+func crossfadeHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+	arg := strings.ToLower(strings.TrimSpace(m.Args()))
+
+	if arg == "" {
+		seconds, err := database.GetCrossfade(chatID)
+		if err != nil {
+			m.Reply(F(chatID, "crossfade_fetch_fail"))
+			return tg.ErrEndGroup
+		}
+		if seconds == 0 {
+			m.Reply(F(chatID, "crossfade_status_off", locales.Arg{
+				"cmd": getCommand(m),
+			}))
+		} else {
+			m.Reply(F(chatID, "crossfade_status", locales.Arg{
+				"seconds": seconds,
+				"cmd":     getCommand(m),
+			}))
+		}
+		return tg.ErrEndGroup
+	}
+
+	// a number of seconds, or "off" which is the same as 0
+	seconds, err := strconv.Atoi(strings.TrimSuffix(arg, "s"))
+	if err != nil {
+		if on, err := utils.ParseBool(arg); err == nil && !on {
+			seconds = 0
+		} else {
+			seconds = -1
+		}
+	}
+	if seconds < 0 || seconds > core.MaxCrossfade {
+		m.Reply(F(chatID, "crossfade_invalid", locales.Arg{
+			"cmd": getCommand(m),
+			"max": core.MaxCrossfade,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetCrossfade(chatID, seconds); err != nil {
+		m.Reply(F(chatID, "crossfade_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	for _, r := range settingsRooms(chatID) {
+		r.SetCrossfade(seconds)
+	}
+
+	if seconds == 0 {
+		m.Reply(F(chatID, "crossfade_disabled"))
+	} else {
+		m.Reply(F(chatID, "crossfade_updated", locales.Arg{
+			"seconds": seconds,
+		}))
+	}
+	return tg.ErrEndGroup
+}
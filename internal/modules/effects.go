@@ -0,0 +1,308 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/effect"] = `<i>Apply audio effects to the current stream.</i>
+
+<u>Usage:</u>
+<b>/effect</b> — Show active effects with toggle buttons
+<b>/effect [name]</b> — Toggle an effect on or off
+<b>/effect off</b> — Turn off all effects and the equalizer
+
+<b>🎛 Effects:</b>
+• <code>bassboost</code> — Stronger low end
+• <code>nightcore</code> — Higher pitch
+• <code>vaporwave</code> — Lower pitch
+• <code>8d</code> — Sound moving around your head
+• <code>karaoke</code> — Removes centered vocals
+• <code>loudnorm</code> — Even out loudness
+
+<b>⚙️ Behavior:</b>
+• Effects can be combined and stay on for the following tracks
+• Playback restarts at the current position, like /speed
+• Pitch effects keep the tempo; pair them with /speed for the sped-up versions
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Examples:</b>
+<code>/effect bassboost</code>
+<code>/effect off</code>`
+
+	helpTexts["/eq"] = `<i>Shape the sound with a ` + strconv.Itoa(len(core.EQBands)) + `-band equalizer.</i>
+
+<u>Usage:</u>
+<b>/eq</b> — Show the current gains
+<b>/eq [gain] [gain] ...</b> — Set gains in dB, from the lowest band up
+<b>/eq off</b> — Flatten the equalizer
+
+<b>🎚 Bands:</b>
+` + eqBandList() + `
+
+<b>⚙️ Behavior:</b>
+• Gains range from -` + strconv.Itoa(int(core.MaxEQGain)) + ` to ` + strconv.Itoa(int(core.MaxEQGain)) + ` dB
+• Bands you leave out stay flat
+• Playback restarts at the current position
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Examples:</b>
+<code>/eq 6 4 2</code> — Warmer bass
+<code>/eq 0 0 0 0 0 2 3 4 4 3</code> — Brighter highs
+<code>/eq off</code>`
+}
+
+func effectHandler(m *tg.NewMessage) error {
+	return handleEffect(m, false)
+}
+
+func ceffectHandler(m *tg.NewMessage) error {
+	return handleEffect(m, true)
+}
+
+func handleEffect(m *tg.NewMessage, cplay bool) error {
+	r, err := getEffectiveRoom(m, cplay)
+	if err != nil {
+		m.Reply(err.Error())
+		return tg.ErrEndGroup
+	}
+
+	chatID := m.ChannelID()
+	if !r.IsActiveChat() || r.Track() == nil {
+		m.Reply(F(chatID, "room_no_active"))
+		return tg.ErrEndGroup
+	}
+
+	name := strings.ToLower(strings.TrimSpace(m.Args()))
+	switch {
+	case name == "":
+		m.Reply(effectsText(chatID, r), &tg.SendOptions{
+			ParseMode:   "HTML",
+			ReplyMarkup: core.GetEffectsMarkup(chatID, r),
+		})
+
+	case name == "off" || name == "reset" || name == "none":
+		if err := r.ClearEffects(); err != nil {
+			m.Reply(F(chatID, "effect_failed", locales.Arg{
+				"error": err.Error(),
+			}))
+			return tg.ErrEndGroup
+		}
+		m.Reply(F(chatID, "effect_cleared", locales.Arg{
+			"user": utils.MentionHTML(m.Sender),
+		}))
+
+	case !core.IsEffect(name):
+		m.Reply(F(chatID, "effect_unknown", locales.Arg{
+			"effects": strings.Join(core.Effects, ", "),
+			"cmd":     getCommand(m),
+		}))
+
+	default:
+		enabled, err := r.ToggleEffect(name)
+		if err != nil {
+			m.Reply(F(chatID, "effect_failed", locales.Arg{
+				"error": err.Error(),
+			}))
+			return tg.ErrEndGroup
+		}
+		m.Reply(F(chatID, utils.IfElse(enabled, "effect_enabled", "effect_disabled"), locales.Arg{
+			"effect": F(chatID, effectButtonKey(name)),
+			"user":   utils.MentionHTML(m.Sender),
+		}))
+	}
+	return tg.ErrEndGroup
+}
+
+func eqHandler(m *tg.NewMessage) error {
+	return handleEQ(m, false)
+}
+
+func ceqHandler(m *tg.NewMessage) error {
+	return handleEQ(m, true)
+}
+
+func handleEQ(m *tg.NewMessage, cplay bool) error {
+	r, err := getEffectiveRoom(m, cplay)
+	if err != nil {
+		m.Reply(err.Error())
+		return tg.ErrEndGroup
+	}
+
+	chatID := m.ChannelID()
+	if !r.IsActiveChat() || r.Track() == nil {
+		m.Reply(F(chatID, "room_no_active"))
+		return tg.ErrEndGroup
+	}
+
+	args := strings.Fields(strings.ToLower(m.Args()))
+	if len(args) == 0 {
+		eq := r.EQ()
+		if eq == nil {
+			m.Reply(F(chatID, "eq_status_flat", locales.Arg{
+				"cmd": getCommand(m),
+			}))
+		} else {
+			m.Reply(F(chatID, "eq_status", locales.Arg{
+				"gains": eqGainList(eq),
+				"cmd":   getCommand(m),
+			}))
+		}
+		return tg.ErrEndGroup
+	}
+
+	var gains []float64
+	if args[0] != "off" && args[0] != "reset" && args[0] != "flat" {
+		if len(args) > len(core.EQBands) {
+			m.Reply(F(chatID, "eq_invalid", locales.Arg{
+				"bands": len(core.EQBands),
+				"max":   int(core.MaxEQGain),
+				"cmd":   getCommand(m),
+			}))
+			return tg.ErrEndGroup
+		}
+		for _, a := range args {
+			g, err := strconv.ParseFloat(strings.TrimSuffix(a, "db"), 64)
+			if err != nil || g < -core.MaxEQGain || g > core.MaxEQGain {
+				m.Reply(F(chatID, "eq_invalid", locales.Arg{
+					"bands": len(core.EQBands),
+					"max":   int(core.MaxEQGain),
+					"cmd":   getCommand(m),
+				}))
+				return tg.ErrEndGroup
+			}
+			gains = append(gains, g)
+		}
+	}
+
+	if err := r.SetEQ(gains); err != nil {
+		m.Reply(F(chatID, "effect_failed", locales.Arg{
+			"error": err.Error(),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if eq := r.EQ(); eq == nil {
+		m.Reply(F(chatID, "eq_flattened", locales.Arg{
+			"user": utils.MentionHTML(m.Sender),
+		}))
+	} else {
+		m.Reply(F(chatID, "eq_updated", locales.Arg{
+			"gains": eqGainList(eq),
+			"user":  utils.MentionHTML(m.Sender),
+		}))
+	}
+	return tg.ErrEndGroup
+}
+
+// handleEffectAction toggles an effect from the buttons of /effect.
+func handleEffectAction(
+	cb *tg.CallbackQuery,
+	r *core.RoomState,
+	action string,
+	opt *tg.CallbackOptions,
+) error {
+	chatID := cb.ChannelID()
+	name := strings.TrimPrefix(action, "fx_")
+
+	var err error
+	if name == "off" {
+		err = r.ClearEffects()
+	} else {
+		_, err = r.ToggleEffect(name)
+	}
+	if err != nil {
+		gologging.ErrorF("Effect %s failed: %v", name, err)
+		cb.Answer(F(chatID, "effect_failed", locales.Arg{
+			"error": err.Error(),
+		}), opt)
+		return tg.ErrEndGroup
+	}
+
+	cb.Answer("")
+	cb.Edit(effectsText(chatID, r), &tg.SendOptions{
+		ParseMode:   "HTML",
+		ReplyMarkup: core.GetEffectsMarkup(chatID, r),
+	})
+	return tg.ErrEndGroup
+}
+
+func effectsText(chatID int64, r *core.RoomState) string {
+	effects := r.Effects()
+	if len(effects) == 0 && r.EQ() == nil {
+		return F(chatID, "effect_status_none")
+	}
+
+	names := make([]string, len(effects))
+	for i, e := range effects {
+		names[i] = F(chatID, effectButtonKey(e))
+	}
+	return F(chatID, "effect_status", locales.Arg{
+		"effects": utils.IfElse(len(names) > 0, strings.Join(names, ", "), "—"),
+		"eq":      utils.IfElse(r.EQ() != nil, eqGainList(r.EQ()), "—"),
+	})
+}
+
+func effectButtonKey(name string) string {
+	return "FX_" + strings.ToUpper(name) + "_BTN"
+}
+
+// eqGainList formats gains as "31Hz +6 · 62Hz +4 ...".
+func eqGainList(gains []float64) string {
+	parts := make([]string, 0, len(gains))
+	for i, g := range gains {
+		if i >= len(core.EQBands) {
+			break
+		}
+		parts = append(parts, eqBandName(core.EQBands[i])+" "+
+			strconv.FormatFloat(g, 'f', -1, 64))
+	}
+	return "<code>" + strings.Join(parts, " · ") + "</code>"
+}
+
+func eqBandList() string {
+	parts := make([]string, len(core.EQBands))
+	for i, f := range core.EQBands {
+		parts[i] = eqBandName(f)
+	}
+	return "<code>" + strings.Join(parts, " · ") + "</code>"
+}
+
+func eqBandName(freq int) string {
+	if freq >= 1000 {
+		return strconv.Itoa(freq/1000) + "kHz"
+	}
+	return strconv.Itoa(freq) + "Hz"
+}
@@ -0,0 +1,150 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strconv"
+	"strings"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/config"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/fairqueue"] = `<i>Share the queue fairly between everyone who requests tracks.</i>
+
+<u>Usage:</u>
+<b>/fairqueue</b> — Show current fair queue settings
+<b>/fairqueue on</b> — Enable fair queue
+<b>/fairqueue off</b> — Disable fair queue
+<b>/fairqueue limit &lt;count&gt;</b> — Max tracks one user can have queued
+<b>/fairqueue limit off</b> — Remove the per-user limit
+
+<b>⚙️ Behavior:</b>
+• With fair queue on, requesters take turns: each next track comes from the user who waited the longest
+• A user queueing a whole playlist no longer blocks everyone else
+• The per-user limit works with or without fair queue
+• Both settings also apply to the linked channel (/cplay)
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Examples:</b>
+<code>/fairqueue on</code>
+<code>/fairqueue limit 5</code>`
+}
+
+func fairQueueHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+	args := strings.Fields(strings.ToLower(m.Args()))
+
+	if len(args) == 0 {
+		fair, err1 := database.GetFairQueue(chatID)
+		limit, err2 := database.GetUserQueueLimit(chatID)
+		if err1 != nil || err2 != nil {
+			m.Reply(F(chatID, "fairqueue_fetch_fail"))
+			return tg.ErrEndGroup
+		}
+
+		limitStr := F(chatID, "disabled")
+		if limit > 0 {
+			limitStr = strconv.Itoa(limit)
+		}
+		m.Reply(F(chatID, "fairqueue_status", locales.Arg{
+			"state": F(chatID, utils.IfElse(fair, "enabled", "disabled")),
+			"limit": limitStr,
+			"cmd":   getCommand(m) + utils.IfElse(fair, " off", " on"),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if args[0] == "limit" {
+		return setUserQueueLimit(m, args[1:])
+	}
+
+	value, err := utils.ParseBool(args[0])
+	if err != nil {
+		m.Reply(F(chatID, "invalid_bool"))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetFairQueue(chatID, value); err != nil {
+		m.Reply(F(chatID, "fairqueue_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	for _, r := range settingsRooms(chatID) {
+		r.SetFairQueue(value)
+	}
+
+	m.Reply(F(chatID, "fairqueue_updated", locales.Arg{
+		"state": F(chatID, utils.IfElse(value, "enabled", "disabled")),
+		"user":  utils.MentionHTML(m.Sender),
+	}))
+	return tg.ErrEndGroup
+}
+
+func setUserQueueLimit(m *tg.NewMessage, args []string) error {
+	chatID := m.ChannelID()
+
+	if len(args) == 0 {
+		m.Reply(F(chatID, "fairqueue_limit_usage", locales.Arg{
+			"cmd": getCommand(m),
+			"max": config.QueueLimit,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	// a number, or "off" which is the same as 0
+	limit, err := strconv.Atoi(args[0])
+	if err != nil {
+		if on, err := utils.ParseBool(args[0]); err == nil && !on {
+			limit = 0
+		} else {
+			limit = -1
+		}
+	}
+	if limit < 0 || limit > config.QueueLimit {
+		m.Reply(F(chatID, "fairqueue_limit_usage", locales.Arg{
+			"cmd": getCommand(m),
+			"max": config.QueueLimit,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetUserQueueLimit(chatID, limit); err != nil {
+		m.Reply(F(chatID, "fairqueue_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	if limit == 0 {
+		m.Reply(F(chatID, "fairqueue_limit_removed"))
+	} else {
+		m.Reply(F(chatID, "fairqueue_limit_updated", locales.Arg{
+			"limit": limit,
+		}))
+	}
+	return tg.ErrEndGroup
+}
@@ -30,6 +30,7 @@ import (
 	"main/internal/config"
 	"main/internal/core"
 	"main/internal/database"
+	"main/internal/server"
 	"main/ntgcalls"
 )
 
@@ -219,6 +220,21 @@ var handlers = []MsgHandlerDef{
 		Handler: speedHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "(effect|effects|fx)",
+		Handler: effectHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "(eq|equalizer)",
+		Handler: eqHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "(volume|vol)",
+		Handler: volumeHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
 	{
 		Pattern: "skip",
 		Handler: skipHandler,
@@ -229,6 +245,16 @@ var handlers = []MsgHandlerDef{
 		Handler: pauseHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "sleep",
+		Handler: sleepHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "stopafter",
+		Handler: stopAfterHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
 	{
 		Pattern: "resume",
 		Handler: resumeHandler,
@@ -274,6 +300,36 @@ var handlers = []MsgHandlerDef{
 		Handler: queueHandler,
 		Filters: []telegram.Filter{superGroupFilter},
 	},
+	{
+		Pattern: "history",
+		Handler: historyHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "lyrics",
+		Handler: lyricsHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "radio",
+		Handler: radioHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "search",
+		Handler: searchHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "lastplayed",
+		Handler: lastPlayedHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "playlist",
+		Handler: playlistHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
 	{
 		Pattern: "clear",
 		Handler: clearHandler,
@@ -294,6 +350,36 @@ var handlers = []MsgHandlerDef{
 		Handler: shuffleHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "voteskip",
+		Handler: voteSkipHandler,
+		Filters: []telegram.Filter{superGroupFilter},
+	},
+	{
+		Pattern: "fairqueue",
+		Handler: fairQueueHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "searchmode",
+		Handler: searchModeHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "schedule",
+		Handler: scheduleHandler,
+		Filters: []telegram.Filter{superGroupFilter, adminFilter},
+	},
+	{
+		Pattern: "crossfade",
+		Handler: crossfadeHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "autoplay",
+		Handler: autoplayHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
 	{
 		Pattern: "(loop|setloop)",
 		Handler: loopHandler,
@@ -319,6 +405,11 @@ var handlers = []MsgHandlerDef{
 		Handler: delAuthHandler,
 		Filters: []telegram.Filter{superGroupFilter, adminFilter},
 	},
+	{
+		Pattern: "apitoken",
+		Handler: apiTokenHandler,
+		Filters: []telegram.Filter{superGroupFilter, adminFilter},
+	},
 	{
 		Pattern: "authlist",
 		Handler: authListHandler,
@@ -341,6 +432,16 @@ var handlers = []MsgHandlerDef{
 		Handler: cpauseHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "csleep",
+		Handler: csleepHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "cstopafter",
+		Handler: cstopAfterHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
 	{
 		Pattern: "cresume",
 		Handler: cresumeHandler,
@@ -416,6 +517,21 @@ var handlers = []MsgHandlerDef{
 		Handler: cspeedHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "(ceffect|ceffects|cfx)",
+		Handler: ceffectHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "(ceq|cequalizer)",
+		Handler: ceqHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "(cvolume|cvol)",
+		Handler: cvolumeHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
 	{
 		Pattern: "creplay",
 		Handler: creplayHandler,
@@ -426,6 +542,26 @@ var handlers = []MsgHandlerDef{
 		Handler: cpositionHandler,
 		Filters: []telegram.Filter{superGroupFilter, authFilter},
 	},
+	{
+		Pattern: "clyrics",
+		Handler: clyricsHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "cradio",
+		Handler: cradioHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "csearch",
+		Handler: csearchHandler,
+		Filters: []telegram.Filter{superGroupFilter, authFilter},
+	},
+	{
+		Pattern: "cschedule",
+		Handler: cscheduleHandler,
+		Filters: []telegram.Filter{superGroupFilter, adminFilter},
+	},
 	{
 		Pattern: "cshuffle",
 		Handler: cshuffleHandler,
@@ -449,6 +585,12 @@ var cbHandlers = []CbHandlerDef{
 	{Pattern: "^bcast_cancel$", Handler: broadcastCancelCB},
 
 	{Pattern: `^room:(\w+)$`, Handler: roomHandle},
+	{Pattern: `^restore:(yes|no):-?\d+$`, Handler: restoreCB},
+	{Pattern: `^history:(p|q):(c|u):-?\d+:\d+$`, Handler: historyCB},
+	{Pattern: `^lyrics:(sync|stop):-?\d+$`, Handler: lyricsCB},
+	{Pattern: `^radio:\d+$`, Handler: radioCB},
+	{Pattern: `^podcast:(e|p):\d+$`, Handler: podcastCB},
+	{Pattern: `^search:\d+$`, Handler: searchCB},
 	{Pattern: "progress", Handler: emptyCBHandler},
 }
 
@@ -478,8 +620,16 @@ func Init(bot *telegram.Client, assistants *core.AssistantManager) {
 	assistants.ForEach(func(a *core.Assistant) {
 		a.Ntg.OnStreamEnd(ntgOnStreamEnd)
 	})
+	core.OnCrossfade = onStreamEndHandler
+	core.OnScheduledStop = onScheduledStop
+	server.GetBroadcast = broadcastProgress
+	registerAPI()
 
 	go MonitorRooms()
+	go MonitorRadio()
+	go MonitorPodcasts()
+	go restoreRooms()
+	go loadSchedules()
 
 	if is, _ := database.GetAutoLeave(); is {
 		go startAutoLeave()
@@ -495,7 +645,9 @@ func Init(bot *telegram.Client, assistants *core.AssistantManager) {
 		"/cmute", "/cunmute", "/cseek", "/cseekback",
 		"/cjump", "/cremove", "/cclear", "/cmove",
 		"/cspeed", "/creplay", "/cposition", "/cshuffle",
-		"/cloop", "/cqueue", "/creload",
+		"/cloop", "/cqueue", "/creload", "/ceffect", "/ceq",
+		"/cvolume", "/clyrics", "/cschedule", "/csleep", "/cstopafter",
+		"/cradio", "/csearch",
 	}
 
 	for _, cmd := range cplayCommands {
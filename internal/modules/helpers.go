@@ -60,6 +60,23 @@ func getEffectiveRoom(m *tg.NewMessage, cplay bool) (*core.RoomState, error) {
 	return r, nil
 }
 
+// settingsRooms returns the running rooms that follow chatID's settings:
+// its own room and the room of its linked channel.
+func settingsRooms(chatID int64) []*core.RoomState {
+	ids := []int64{chatID}
+	if cplayID, err := database.GetCPlayID(chatID); err == nil && cplayID != 0 {
+		ids = append(ids, cplayID)
+	}
+
+	var rooms []*core.RoomState
+	for _, id := range ids {
+		if r, ok := core.GetRoom(id, nil); ok {
+			rooms = append(rooms, r)
+		}
+	}
+	return rooms
+}
+
 func isMaintenanceBlocked(userID int64) bool {
 	isMaint, _ := database.IsMaintenance()
 	if !isMaint {
@@ -102,7 +119,12 @@ func isLogger() (l bool) {
 	return l
 }
 
-func sendPlayLogs(m *tg.NewMessage, track *state.Track, queued bool) {
+func sendPlayLogs(
+	m *tg.NewMessage,
+	user *tg.UserObj,
+	track *state.Track,
+	queued bool,
+) {
 	if config.LoggerID == 0 || config.LoggerID == m.ChatID() ||
 		config.LoggerID == m.ChannelID() {
 		return
@@ -166,12 +188,12 @@ func sendPlayLogs(m *tg.NewMessage, track *state.Track, queued bool) {
 
 	// Requested by
 	fmt.Fprintf(&sb, "<b>%s</b> ", F(chatID, "logger_requested_by"))
-	if m.Sender.Username != "" {
-		fmt.Fprintf(&sb, "@%s", m.Sender.Username)
+	if user.Username != "" {
+		fmt.Fprintf(&sb, "@%s", user.Username)
 	} else {
-		sb.WriteString(utils.MentionHTML(m.Sender))
+		sb.WriteString(utils.MentionHTML(user))
 	}
-	fmt.Fprintf(&sb, " (<code>%d</code>)\n", m.Sender.ID)
+	fmt.Fprintf(&sb, " (<code>%d</code>)\n", user.ID)
 
 	// Timestamp
 	fmt.Fprintf(&sb, "<b>%s</b> %s",
@@ -0,0 +1,342 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+const historyPageSize = 10
+
+func init() {
+	helpTexts["/history"] = `<i>Show recently played tracks.</i>
+
+<u>Usage:</u>
+<b>/history</b> — Tracks played in this chat
+<b>/history me</b> — Tracks you requested, in any chat
+
+<b>⚙️ Features:</b>
+• Shows how each track ended (finished, skipped, stopped)
+• Page through older entries with the arrow buttons
+• Tap a number to queue that track again
+
+<b>💡 Related Commands:</b>
+• <code>/lastplayed</code> - Last played track only
+• <code>/queue</code> - Current queue`
+
+	helpTexts["/lastplayed"] = `<i>Show the last track played in this chat.</i>
+
+<u>Usage:</u>
+<b>/lastplayed</b> — Last finished, skipped or stopped track
+
+<b>💡 Tip:</b>
+Use the button to queue it again.`
+}
+
+func historyHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+
+	user := false
+	ownerID := chatID
+	if args := strings.Fields(m.Text()); len(args) > 1 &&
+		strings.EqualFold(args[1], "me") {
+		user = true
+		ownerID = m.SenderID()
+	}
+
+	entries, err := getHistoryEntries(ownerID, user)
+	if err != nil {
+		m.Reply(F(chatID, "history_fetch_failed"))
+		return tg.ErrEndGroup
+	}
+	if len(entries) == 0 {
+		m.Reply(F(chatID, "history_empty"))
+		return tg.ErrEndGroup
+	}
+
+	text, markup := buildHistoryPage(chatID, ownerID, user, entries, 0)
+	m.Reply(text, &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: markup})
+	return tg.ErrEndGroup
+}
+
+func lastPlayedHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+
+	entries, err := database.GetChatHistory(chatID)
+	if err != nil {
+		m.Reply(F(chatID, "history_fetch_failed"))
+		return tg.ErrEndGroup
+	}
+	if len(entries) == 0 {
+		m.Reply(F(chatID, "history_empty"))
+		return tg.ErrEndGroup
+	}
+
+	e := entries[0]
+	text := F(chatID, "lastplayed_text", locales.Arg{
+		"url":      e.Track.URL,
+		"title":    html.EscapeString(utils.ShortTitle(e.Track.Title, 35)),
+		"duration": formatDuration(e.Track.Duration),
+		"by":       e.Track.Requester,
+		"reason":   historyReason(chatID, e.Reason),
+		"ago":      timeAgo(chatID, e.EndedAt),
+	})
+
+	markup := tg.NewKeyboard().
+		AddRow(
+			tg.Button.Data(
+				F(chatID, "HISTORY_REQUEUE_BTN"),
+				historyData("q", chatID, false, e.ID),
+			),
+			tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"),
+		).
+		Build()
+
+	m.Reply(text, &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: markup})
+	return tg.ErrEndGroup
+}
+
+func getHistoryEntries(ownerID int64, user bool) ([]*state.HistoryEntry, error) {
+	if user {
+		return database.GetUserHistory(ownerID)
+	}
+	return database.GetChatHistory(ownerID)
+}
+
+// historyData builds callback data: history:<action>:<c|u>:<owner>:<value>
+func historyData(action string, ownerID int64, user bool, value int64) string {
+	scope := "c"
+	if user {
+		scope = "u"
+	}
+	return fmt.Sprintf("history:%s:%s:%d:%d", action, scope, ownerID, value)
+}
+
+func buildHistoryPage(
+	chatID, ownerID int64,
+	user bool,
+	entries []*state.HistoryEntry,
+	page int,
+) (string, tg.ReplyMarkup) {
+	pages := (len(entries) + historyPageSize - 1) / historyPageSize
+	if page < 0 {
+		page = 0
+	} else if page >= pages {
+		page = pages - 1
+	}
+
+	start := page * historyPageSize
+	end := min(start+historyPageSize, len(entries))
+
+	var b strings.Builder
+	if user {
+		b.WriteString(F(chatID, "history_header_user"))
+	} else {
+		b.WriteString(F(chatID, "history_header_chat"))
+	}
+	b.WriteString("\n\n")
+
+	kb := tg.NewKeyboard()
+	var row []tg.KeyboardButton
+
+	for i, e := range entries[start:end] {
+		n := start + i + 1
+		b.WriteString(fmt.Sprintf(
+			"%d. <a href=\"%s\">%s</a> [%s]\n    %s · %s\n",
+			n,
+			e.Track.URL,
+			html.EscapeString(utils.ShortTitle(e.Track.Title, 35)),
+			formatDuration(e.Track.Duration),
+			historyReason(chatID, e.Reason),
+			timeAgo(chatID, e.EndedAt),
+		))
+
+		row = append(row, tg.Button.Data(
+			strconv.Itoa(n),
+			historyData("q", ownerID, user, e.ID),
+		))
+		if len(row) == 5 {
+			kb.AddRow(row...)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		kb.AddRow(row...)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(F(chatID, "history_footer", locales.Arg{
+		"page":  page + 1,
+		"pages": pages,
+	}))
+
+	var nav []tg.KeyboardButton
+	if page > 0 {
+		nav = append(nav, tg.Button.Data(
+			"◀", historyData("p", ownerID, user, int64(page-1)),
+		))
+	}
+	nav = append(nav, tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"))
+	if page < pages-1 {
+		nav = append(nav, tg.Button.Data(
+			"▶", historyData("p", ownerID, user, int64(page+1)),
+		))
+	}
+	kb.AddRow(nav...)
+
+	return b.String(), kb.Build()
+}
+
+func historyCB(cb *tg.CallbackQuery) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	// history:<action>:<c|u>:<owner>:<value>
+	parts := strings.Split(cb.DataString(), ":")
+	if len(parts) != 5 {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	user := parts[2] == "u"
+	ownerID, err1 := strconv.ParseInt(parts[3], 10, 64)
+	value, err2 := strconv.ParseInt(parts[4], 10, 64)
+	if err1 != nil || err2 != nil || (!user && parts[2] != "c") {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	// the owner comes from the callback data, so it can't be trusted: a
+	// chat history only belongs to its own chat and a user's history only
+	// to that user
+	if user && ownerID != cb.SenderID {
+		cb.Answer(F(chatID, "history_not_yours"), opt)
+		return tg.ErrEndGroup
+	}
+	if !user && ownerID != chatID {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	switch parts[1] {
+	case "p":
+		entries, err := getHistoryEntries(ownerID, user)
+		if err != nil || len(entries) == 0 {
+			cb.Answer(F(chatID, "history_empty"), opt)
+			return tg.ErrEndGroup
+		}
+		text, markup := buildHistoryPage(chatID, ownerID, user, entries, int(value))
+		cb.Answer("")
+		cb.Edit(text, &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: markup})
+	case "q":
+		return requeueHistoryEntry(cb, ownerID, user, value)
+	default:
+		cb.Answer(F(chatID, "invalid_request"), opt)
+	}
+	return tg.ErrEndGroup
+}
+
+func requeueHistoryEntry(
+	cb *tg.CallbackQuery,
+	ownerID int64,
+	user bool,
+	entryID int64,
+) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	if !checkFloodControl(cb, chatID, opt) {
+		return tg.ErrEndGroup
+	}
+
+	e, err := database.GetHistoryEntry(ownerID, entryID, user)
+	if err != nil || e.Track == nil {
+		cb.Answer(F(chatID, "history_entry_missing"), opt)
+		return tg.ErrEndGroup
+	}
+
+	msg, err := cb.GetMessage()
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	ass, err := core.Assistants.ForChat(chatID)
+	if err != nil {
+		cb.Answer(getErrorMessage(chatID, err), opt)
+		return tg.ErrEndGroup
+	}
+	r, _ := core.GetRoom(chatID, ass, true)
+	r.SetCPlay(false)
+
+	cb.Answer(F(chatID, "history_requeueing"))
+
+	replyMsg, err := msg.Respond(F(chatID, "searching"))
+	if err != nil {
+		return tg.ErrEndGroup
+	}
+
+	// copy, playTracksAndRespond sets the requester on it
+	t := *e.Track
+	return playResolvedTracks(
+		msg, cb.Sender, replyMsg, r, []*state.Track{&t}, false,
+	)
+}
+
+func historyReason(chatID int64, reason state.EndReason) string {
+	switch reason {
+	case state.EndFinished:
+		return F(chatID, "history_reason_finished")
+	case state.EndSkipped:
+		return F(chatID, "history_reason_skipped")
+	default:
+		return F(chatID, "history_reason_stopped")
+	}
+}
+
+func timeAgo(chatID int64, unix int64) string {
+	d := time.Since(time.Unix(unix, 0))
+
+	var t string
+	switch {
+	case d < time.Minute:
+		return F(chatID, "time_just_now")
+	case d < time.Hour:
+		t = strconv.Itoa(int(d.Minutes())) + "m"
+	case d < 24*time.Hour:
+		t = strconv.Itoa(int(d.Hours())) + "h"
+	default:
+		t = strconv.Itoa(int(d.Hours()/24)) + "d"
+	}
+	return F(chatID, "time_ago", locales.Arg{"time": t})
+}
@@ -0,0 +1,412 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"context"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/lyrics"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/lyrics"] = `<i>Show the lyrics of the current track or any song.</i>
+
+<u>Usage:</u>
+<b>/lyrics</b> — Lyrics of the track playing now
+<b>/lyrics &lt;song&gt;</b> — Search lyrics for a song
+
+<b>⚙️ Behavior:</b>
+• Local lyric files are checked first, then LRCLIB
+• When timed lyrics exist for the current track, tap <b>Sync</b> to get a message that follows playback line by line, also across seeks, pauses and speed changes
+• The synced message stops when the track ends
+
+<b>💡 Examples:</b>
+<code>/lyrics</code>
+<code>/lyrics Coldplay Yellow</code>`
+}
+
+const (
+	// lyricsMaxLen keeps the lyrics message below Telegram's length limit.
+	lyricsMaxLen = 3500
+	// lyricsMinEdit spaces out edits of a synced lyrics message.
+	lyricsMinEdit = 3 * time.Second
+	// lyricsBefore and lyricsAfter are the lines shown around the current one.
+	lyricsBefore = 2
+	lyricsAfter  = 3
+)
+
+type lyricsSession struct {
+	cancel context.CancelFunc
+}
+
+var (
+	lyricsSessions   = make(map[int64]*lyricsSession) // room ID -> sync
+	lyricsSessionsMu sync.Mutex
+)
+
+func lyricsHandler(m *tg.NewMessage) error {
+	return handleLyrics(m, false)
+}
+
+func clyricsHandler(m *tg.NewMessage) error {
+	return handleLyrics(m, true)
+}
+
+func handleLyrics(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	query := strings.TrimSpace(m.Args())
+
+	var (
+		r        *core.RoomState
+		duration int
+	)
+	if query == "" {
+		r = lyricsRoom(chatID, cplay)
+		if r == nil || !r.IsActiveChat() || r.Track() == nil {
+			m.Reply(F(chatID, "lyrics_usage", locales.Arg{
+				"cmd": getCommand(m),
+			}))
+			return tg.ErrEndGroup
+		}
+		query = lyrics.CleanTitle(r.Track().Title)
+		duration = r.Track().Duration
+	}
+
+	replyMsg, err := m.Reply(F(chatID, "lyrics_searching", locales.Arg{
+		"query": html.EscapeString(query),
+	}))
+	if err != nil {
+		return tg.ErrEndGroup
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	l, err := lyrics.Get(ctx, query, duration)
+	if err != nil {
+		utils.EOR(replyMsg, F(chatID, "lyrics_not_found", locales.Arg{
+			"query": html.EscapeString(query),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	opt := &tg.SendOptions{ParseMode: "HTML"}
+	if r != nil && len(l.Synced) > 0 {
+		opt.ReplyMarkup = tg.NewKeyboard().AddRow(
+			tg.Button.Data(
+				F(chatID, "LYRICS_SYNC_BTN"),
+				"lyrics:sync:"+strconv.FormatInt(r.ChatID(), 10),
+			),
+		).Build()
+	}
+
+	utils.EOR(replyMsg, lyricsText(chatID, l, query), opt)
+	return tg.ErrEndGroup
+}
+
+// lyricsRoom returns the room of a chat or its linked channel without
+// creating it.
+func lyricsRoom(chatID int64, cplay bool) *core.RoomState {
+	if cplay {
+		cplayID, err := database.GetCPlayID(chatID)
+		if err != nil || cplayID == 0 {
+			return nil
+		}
+		chatID = cplayID
+	}
+	r, _ := core.GetRoom(chatID, nil)
+	return r
+}
+
+func lyricsCB(cb *tg.CallbackQuery) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	// lyrics:<sync|stop>:<room>
+	parts := strings.Split(cb.DataString(), ":")
+	if len(parts) != 3 {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+	roomID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err == nil && roomID != chatID {
+		// only the chat itself or its linked channel
+		if r := lyricsRoom(chatID, true); r == nil || r.ChatID() != roomID {
+			err = strconv.ErrSyntax
+		}
+	}
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	if parts[1] == "stop" {
+		stopLyricsSync(roomID)
+		cb.Answer(F(chatID, "lyrics_sync_stopped"))
+		cb.Edit(F(chatID, "lyrics_sync_ended"))
+		return tg.ErrEndGroup
+	}
+
+	if !checkFloodControl(cb, chatID, opt) {
+		return tg.ErrEndGroup
+	}
+
+	r, ok := core.GetRoom(roomID, nil)
+	if !ok || !r.IsActiveChat() || r.Track() == nil {
+		cb.Answer(F(chatID, "room_no_active"), opt)
+		return tg.ErrEndGroup
+	}
+	t := r.Track()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	l, err := lyrics.Get(ctx, lyrics.CleanTitle(t.Title), t.Duration)
+	if err != nil || len(l.Synced) == 0 {
+		cb.Answer(F(chatID, "lyrics_no_synced"), opt)
+		return tg.ErrEndGroup
+	}
+
+	msg, err := cb.GetMessage()
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	cb.Answer("")
+	startLyricsSync(r, msg, l, t.ID, chatID)
+	return tg.ErrEndGroup
+}
+
+// startLyricsSync makes msg follow the playback of track trackID in r,
+// replacing an earlier synced message of the room.
+func startLyricsSync(
+	r *core.RoomState,
+	msg *tg.NewMessage,
+	l *state.Lyrics,
+	trackID string,
+	chatID int64,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &lyricsSession{cancel: cancel}
+
+	lyricsSessionsMu.Lock()
+	if old, ok := lyricsSessions[r.ChatID()]; ok {
+		old.cancel()
+	}
+	lyricsSessions[r.ChatID()] = s
+	lyricsSessionsMu.Unlock()
+
+	go func() {
+		defer func() {
+			lyricsSessionsMu.Lock()
+			if lyricsSessions[r.ChatID()] == s {
+				delete(lyricsSessions, r.ChatID())
+			}
+			lyricsSessionsMu.Unlock()
+			cancel()
+		}()
+		runLyricsSync(ctx, r, msg, l, trackID, chatID)
+	}()
+}
+
+func stopLyricsSync(roomID int64) {
+	lyricsSessionsMu.Lock()
+	defer lyricsSessionsMu.Unlock()
+
+	if s, ok := lyricsSessions[roomID]; ok {
+		s.cancel()
+		delete(lyricsSessions, roomID)
+	}
+}
+
+// runLyricsSync edits msg whenever playback reaches another line. It keeps
+// its own clock from the room's position and speed, and resyncs on the
+// room events that move it.
+func runLyricsSync(
+	ctx context.Context,
+	r *core.RoomState,
+	msg *tg.NewMessage,
+	l *state.Lyrics,
+	trackID string,
+	chatID int64,
+) {
+	events, unsubscribe := core.Subscribe(r.ChatID(), 16)
+	defer unsubscribe()
+
+	var (
+		anchorPos  time.Duration
+		anchorAt   time.Time
+		speed      float64
+		paused     bool
+		line       = -2 // shown line, -1 before the first one
+		lastEdit   time.Time
+		floodUntil time.Time
+	)
+
+	sample := func() bool {
+		r.Parse()
+		t := r.Track()
+		if !r.IsActiveChat() || t == nil || t.ID != trackID {
+			return false
+		}
+		anchorPos = time.Duration(r.Position()) * time.Second
+		anchorAt = time.Now()
+		speed = max(r.Speed(), 0.1)
+		paused = r.IsPaused()
+		return true
+	}
+	if !sample() {
+		return
+	}
+
+	stopBtn := tg.NewKeyboard().AddRow(
+		tg.Button.Data(
+			F(chatID, "LYRICS_STOP_BTN"),
+			"lyrics:stop:"+strconv.FormatInt(r.ChatID(), 10),
+		),
+	).Build()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			switch e.Type {
+			case core.EventTrackEnded, core.EventRoomDestroyed:
+				msg.Edit(F(chatID, "lyrics_sync_ended"))
+				return
+			case core.EventSeeked, core.EventSpeedChanged, core.EventPaused, core.EventResumed:
+				if !sample() {
+					msg.Edit(F(chatID, "lyrics_sync_ended"))
+					return
+				}
+				timer.Reset(0)
+			}
+
+		case <-timer.C:
+			if paused {
+				continue
+			}
+
+			pos := anchorPos + time.Duration(float64(time.Since(anchorAt))*speed)
+			idx := lyrics.LineAt(l.Synced, pos)
+
+			if idx != line && time.Now().After(floodUntil) &&
+				time.Since(lastEdit) >= lyricsMinEdit {
+				_, err := msg.Edit(syncedLyricsText(chatID, l, idx), &tg.SendOptions{
+					ParseMode:   "HTML",
+					ReplyMarkup: stopBtn,
+				})
+				if wait := tg.GetFloodWait(err); wait > 0 {
+					floodUntil = time.Now().Add(time.Duration(wait) * time.Second)
+				} else if err != nil && !tg.MatchError(err, "MESSAGE_NOT_MODIFIED") {
+					gologging.DebugF("Stopping synced lyrics in %d: %v", chatID, err)
+					return
+				} else {
+					line = idx
+					lastEdit = time.Now()
+				}
+			}
+
+			// wake up for a pending edit or for the next line
+			next := time.Duration(-1)
+			if idx != line {
+				next = max(lyricsMinEdit-time.Since(lastEdit), time.Until(floodUntil))
+			} else if idx+1 < len(l.Synced) {
+				next = time.Duration(float64(l.Synced[idx+1].At-pos) / speed)
+			}
+			if next >= 0 {
+				timer.Reset(max(next, 100*time.Millisecond))
+			}
+		}
+	}
+}
+
+func lyricsText(chatID int64, l *state.Lyrics, query string) string {
+	title := l.Title
+	if title == "" {
+		title = query
+	}
+	if l.Artist != "" {
+		title = l.Artist + " — " + title
+	}
+
+	text := []rune(l.Plain)
+	body := string(text)
+	if len(text) > lyricsMaxLen {
+		body = strings.TrimSpace(string(text[:lyricsMaxLen])) + "\n…"
+	}
+
+	return F(chatID, "lyrics_text", locales.Arg{
+		"title":  html.EscapeString(title),
+		"lyrics": html.EscapeString(body),
+		"source": html.EscapeString(l.Source),
+	})
+}
+
+// syncedLyricsText renders the lines around idx with the current one
+// highlighted.
+func syncedLyricsText(chatID int64, l *state.Lyrics, idx int) string {
+	var b strings.Builder
+	for i := max(idx-lyricsBefore, 0); i <= idx+lyricsAfter && i < len(l.Synced); i++ {
+		text := html.EscapeString(l.Synced[i].Text)
+		if text == "" {
+			text = "♪"
+		}
+		switch {
+		case i < idx:
+			b.WriteString("<i>" + text + "</i>")
+		case i == idx:
+			b.WriteString("<b>▸ " + text + "</b>")
+		default:
+			b.WriteString(text)
+		}
+		b.WriteString("\n")
+	}
+	if idx < 0 {
+		b.WriteString("\n♪")
+	}
+
+	return F(chatID, "lyrics_synced", locales.Arg{
+		"title": html.EscapeString(utils.IfElse(l.Title != "", l.Title, "…")),
+		"lines": strings.TrimSpace(b.String()),
+	})
+}
@@ -21,6 +21,9 @@
 package modules
 
 import (
+	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Laky-64/gologging"
@@ -28,68 +31,217 @@ import (
 
 	"main/internal/core"
 	"main/internal/database"
+	"main/internal/utils"
 )
 
+const (
+	// progressMinDelay keeps checks apart on very short tracks and is the
+	// delay used after a seek, resume or speed change.
+	progressMinDelay = 2 * time.Second
+	// progressMaxBackoff caps how long repeated flood waits pause a chat.
+	progressMaxBackoff = 5 * time.Minute
+	// liveStep is how often, in seconds, the elapsed time of a live
+	// stream is redrawn.
+	liveStep = 60
+)
+
+// progressEditSlots bounds how many now playing messages are edited at once.
+var progressEditSlots = make(chan struct{}, 20)
+
+type progressState struct {
+	timer      *time.Timer
+	mysticID   int32  // message the bar was last rendered into
+	bar        string // last rendered progress bar
+	sleep      int    // last rendered sleep timer, in minutes
+	floods     int    // flood waits in a row
+	floodUntil time.Time
+}
+
+var (
+	progress   = make(map[int64]*progressState)
+	progressMu sync.Mutex
+)
+
+// MonitorRooms keeps the now playing buttons up to date. Checks are
+// scheduled from room events for when the bar next moves, so paused and
+// idle rooms cost nothing.
 func MonitorRooms() {
-	ticker := time.NewTicker(4 * time.Second)
-	defer ticker.Stop()
-
-	sem := make(chan struct{}, 20)
-
-	for range ticker.C {
-		for _, chatID := range core.GetAllRoomIDs() {
-			sem <- struct{}{}
-			go func(id int64) {
-				defer func() { <-sem }()
-				ass, err := core.Assistants.ForChat(id)
-				if err != nil {
-					gologging.ErrorF(
-						"Failed to get Assistant for %d: %v",
-						id,
-						err,
-					)
-					return
-				}
-
-				r, ok := core.GetRoom(id, ass)
-				if !ok {
-					gologging.DebugF(
-						"Room not exists for %d returning..",
-						chatID,
-					)
-					return
-				}
-				if !r.IsActiveChat() {
-					return
-				}
-
-				if r.IsPaused() {
-					gologging.DebugF("Room paused for %d returning..", chatID)
-
-					return
-				}
-
-				r.Parse()
-				mystic := r.GetMystic()
-				if mystic == nil {
-					gologging.DebugF("mystic is nil for %d returning..", chatID)
-
-					return
-				}
-				chatID := id
-				if r.IsCPlay() {
-					cid, err := database.GetChatIDFromCPlayID(id)
-					if err == nil {
-						chatID = cid
-					}
-				}
-				markup := core.GetPlayMarkup(chatID, r, false)
-				opts := &telegram.SendOptions{
-					ReplyMarkup: markup,
-					Entities:    mystic.Message.Entities,
-				}
-				mystic.Edit(mystic.Text(), opts)
-			}(chatID)
+	events, _ := core.Subscribe(0, 256)
+
+	for e := range events {
+		switch e.Type {
+		case core.EventTrackStarted:
+			if e.Track != nil {
+				scheduleProgress(e.ChatID, progressDelay(e.Position, e.Track.Duration, e.Speed))
+			}
+		case core.EventResumed, core.EventSeeked, core.EventSpeedChanged:
+			scheduleProgress(e.ChatID, progressMinDelay)
+		case core.EventPaused:
+			stopProgress(e.ChatID, false)
+		case core.EventRoomDestroyed:
+			stopProgress(e.ChatID, true)
+		}
+	}
+}
+
+// progressDelay returns how long it takes a track at pos to reach the next
+// step of its progress bar, one step being a tenth of the track.
+func progressDelay(pos, duration int, speed float64) time.Duration {
+	if duration <= 0 {
+		return progressMinDelay
+	}
+	if speed <= 0 {
+		speed = 1
+	}
+
+	step := float64(duration) / 10
+	next := (math.Floor(float64(pos)/step) + 1) * step
+	// a second late, so the bar has surely moved on
+	wait := (next-float64(pos))/speed + 1
+	return max(time.Duration(wait*float64(time.Second)), progressMinDelay)
+}
+
+// sleepDelay returns how long it takes the sleep timer, shown in whole
+// minutes, to drop by one. It's 0 when no timer is left.
+func sleepDelay(left time.Duration) time.Duration {
+	if left <= 0 {
+		return 0
+	}
+	wait := left % time.Minute
+	if wait == 0 {
+		wait = time.Minute
+	}
+	return max(wait+time.Second, progressMinDelay)
+}
+
+func scheduleProgress(chatID int64, delay time.Duration) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+
+	s, ok := progress[chatID]
+	if !ok {
+		s = &progressState{}
+		progress[chatID] = s
+	}
+	delay = max(delay, time.Until(s.floodUntil))
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(delay, func() { refreshProgress(chatID) })
+}
+
+func stopProgress(chatID int64, forget bool) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+
+	s, ok := progress[chatID]
+	if !ok {
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if forget {
+		delete(progress, chatID)
+	}
+}
+
+// refreshProgress edits the now playing message of chatID if its progress
+// bar or sleep timer changed since the last edit, then schedules the next
+// check. Live tracks have no bar, their elapsed time is redrawn every
+// liveStep seconds instead.
+func refreshProgress(chatID int64) {
+	r, ok := core.GetRoom(chatID, nil)
+	if !ok || !r.IsActiveChat() || r.IsPaused() {
+		return
+	}
+
+	r.Parse()
+	t := r.Track()
+	if t == nil {
+		return
+	}
+
+	var bar string
+	var next time.Duration
+	pos := r.Position()
+	switch {
+	case t.IsLive:
+		bar = strconv.Itoa(pos / liveStep)
+		next = max(time.Duration(liveStep-pos%liveStep+1)*time.Second, progressMinDelay)
+	case t.Duration > 0:
+		bar = utils.GetProgressBar(pos, t.Duration)
+		next = progressDelay(pos, t.Duration, r.Speed())
+	}
+	sleep := r.SleepMinutes()
+	if d := sleepDelay(r.SleepRemaining()); d > 0 && (next == 0 || d < next) {
+		next = d
+	}
+
+	mystic := r.GetMystic()
+	if mystic == nil {
+		if next > 0 {
+			scheduleProgress(chatID, next)
 		}
+		return
+	}
+
+	progressMu.Lock()
+	s, ok := progress[chatID]
+	changed := ok && (s.mysticID != mystic.ID || s.bar != bar || s.sleep != sleep)
+	progressMu.Unlock()
+	if !ok {
+		// destroyed meanwhile
+		return
+	}
+
+	if changed {
+		progressEditSlots <- struct{}{}
+		err := editProgress(r, mystic)
+		<-progressEditSlots
+
+		progressMu.Lock()
+		if wait := telegram.GetFloodWait(err); wait > 0 {
+			s.floods++
+			backoff := time.Duration(wait) * time.Second << min(s.floods-1, 4)
+			s.floodUntil = time.Now().Add(min(backoff, progressMaxBackoff))
+			gologging.DebugF(
+				"FloodWait editing progress in %d, backing off for %s",
+				chatID, time.Until(s.floodUntil).Round(time.Second),
+			)
+		} else {
+			if err != nil && !telegram.MatchError(err, "MESSAGE_NOT_MODIFIED") {
+				gologging.DebugF("Failed to edit progress in %d: %v", chatID, err)
+			}
+			s.floods = 0
+			s.mysticID = mystic.ID
+			s.bar = bar
+			s.sleep = sleep
+		}
+		progressMu.Unlock()
+	}
+
+	if next > 0 {
+		scheduleProgress(chatID, next)
+	}
+}
+
+func editProgress(r *core.RoomState, mystic *telegram.NewMessage) error {
+	chatID := r.ChatID()
+	if r.IsCPlay() {
+		cid, err := database.GetChatIDFromCPlayID(chatID)
+		if err == nil {
+			chatID = cid
+		}
+	}
+
+	markup := core.GetPlayMarkup(chatID, r, false)
+	opts := &telegram.SendOptions{
+		ReplyMarkup: markup,
+		Entities:    mystic.Message.Entities,
 	}
+	_, err := mystic.Edit(mystic.Text(), opts)
+	return err
 }
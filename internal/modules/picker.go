@@ -0,0 +1,128 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"fmt"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+// picker holds the tracks offered on a message for its user to pick one
+// from, as /search, /radio and podcast feeds do.
+type picker struct {
+	title  string // shown above paged pickers, e.g. a feed title
+	tracks []*state.Track
+	userID int64
+	cplay  bool
+	force  bool
+}
+
+func pickKey(chatID int64, msgID int32) string {
+	return fmt.Sprintf("%d:%d", chatID, msgID)
+}
+
+// getPicker returns the picker of the message cb was pressed on if it is
+// still open and cb comes from its user, otherwise cb is answered with the
+// "<name>_expired" or "<name>_not_yours" text.
+func getPicker(
+	cb *tg.CallbackQuery,
+	picks *utils.Cache[string, *picker],
+	name string,
+) (*picker, bool) {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	pick, ok := picks.Get(pickKey(chatID, cb.MessageID))
+	if !ok {
+		cb.Answer(F(chatID, name+"_expired"), opt)
+		return nil, false
+	}
+	if cb.SenderID != pick.userID {
+		cb.Answer(F(chatID, name+"_not_yours"), opt)
+		return nil, false
+	}
+	return pick, true
+}
+
+// playPicked closes the picker cb was pressed on and plays its idx'th
+// track in the room it was opened for. The picker message says status
+// until the track is queued.
+func playPicked(
+	cb *tg.CallbackQuery,
+	picks *utils.Cache[string, *picker],
+	pick *picker,
+	idx int,
+	name string,
+	status func(t *state.Track) string,
+) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	if idx < 0 || idx >= len(pick.tracks) {
+		cb.Answer(F(chatID, name+"_expired"), opt)
+		return tg.ErrEndGroup
+	}
+
+	if !checkFloodControl(cb, chatID, opt) {
+		return tg.ErrEndGroup
+	}
+
+	msg, err := cb.GetMessage()
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	r, err := getEffectiveRoom(msg, pick.cplay)
+	if err != nil {
+		cb.Answer(err.Error(), opt)
+		return tg.ErrEndGroup
+	}
+	r.SetCPlay(pick.cplay)
+	r.Parse()
+
+	if len(r.Queue()) >= config.QueueLimit {
+		cb.Answer(F(chatID, "queue_limit_reached", locales.Arg{
+			"limit": config.QueueLimit,
+		}), opt)
+		return tg.ErrEndGroup
+	}
+
+	picks.Delete(pickKey(chatID, cb.MessageID))
+	// a copy, so queueing it doesn't change cached results
+	track := *pick.tracks[idx]
+	cb.Answer("")
+
+	replyMsg, err := utils.EOR(msg, status(&track))
+	if err != nil || replyMsg == nil {
+		return tg.ErrEndGroup
+	}
+
+	return playResolvedTracks(
+		msg, cb.Sender, replyMsg, r, []*state.Track{&track}, pick.force,
+	)
+}
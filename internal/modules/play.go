@@ -56,11 +56,15 @@ func init() {
 <u>Usage:</u>
 <b>/play [query/URL]</b> — Search and play a song
 <b>/play [reply to audio/video]</b> — Play replied media
+<b>/play [feed URL] [latest|number|search]</b> — Play a podcast episode
 
 <b>🎵 Supported Sources:</b>
 • YouTube (videos, playlists)
 • Spotify (tracks, albums, playlists)
-• SoundCloud
+• Apple Music, Deezer, JioSaavn (songs, albums, playlists)
+• SoundCloud, Bandcamp (tracks, albums), Mixcloud (mixes)
+• Twitch and Kick live channels
+• Podcast RSS/Atom feeds and Apple Podcasts shows
 • Direct audio/video links
 
 <b>⚙️ Features:</b>
@@ -77,6 +81,9 @@ func init() {
 <b>⚠️ Notes:</b>
 • Bot must have proper permissions in voice chat
 • Tracks exceeding duration limit will be skipped
+• Live channels play until the broadcast ends and cannot be seeked
+• Podcast episodes continue where you stopped them last time
+• Use <code>/search</code> to pick among the results, or <code>/searchmode on</code> to always do so
 • Use <code>/queue</code> to view upcoming tracks
 • Use <code>/fplay</code> to force play (skip queue)`
 
@@ -279,19 +286,39 @@ func cplayHandler(m *telegram.NewMessage) error {
 }
 
 func handlePlay(m *telegram.NewMessage, opts *playOpts) error {
-	mention := utils.MentionHTML(m.Sender)
-
 	r, replyMsg, err := prepareRoomAndSearchMessage(m, opts.CPlay)
 	if err != nil {
 		return telegram.ErrEndGroup
 	}
 
-	tracks, isActive, err := fetchTracksAndCheckStatus(
-		m,
-		replyMsg,
-		r,
-		opts.Video,
-	)
+	if feedURL, arg, ok := podcastQuery(m.Args()); ok {
+		return handlePodcastPlay(m, replyMsg, r, feedURL, arg, opts)
+	}
+
+	if useSearchPicker(m) {
+		return showSearchResults(m, replyMsg, m.Args(), opts)
+	}
+
+	tracks, err := fetchTracks(m, replyMsg, opts.Video)
+	if err != nil {
+		return telegram.ErrEndGroup
+	}
+
+	return playResolvedTracks(m, m.Sender, replyMsg, r, tracks, opts.Force)
+}
+
+// playResolvedTracks runs everything /play does once the tracks are known:
+// voice chat checks, limits, download and the now playing reply.
+// m is the message the request belongs to and user the one who asked.
+func playResolvedTracks(
+	m *telegram.NewMessage,
+	user *telegram.UserObj,
+	replyMsg *telegram.NewMessage,
+	r *core.RoomState,
+	tracks []*state.Track,
+	force bool,
+) error {
+	isActive, err := checkVoiceChatStatus(replyMsg, r)
 	if err != nil {
 		return telegram.ErrEndGroup
 	}
@@ -301,9 +328,16 @@ func handlePlay(m *telegram.NewMessage, opts *playOpts) error {
 		return telegram.ErrEndGroup
 	}
 
+	tracks, availableSlots, err = applyUserQueueLimit(
+		replyMsg, r, user, tracks, availableSlots, !isActive || force,
+	)
+	if err != nil {
+		return telegram.ErrEndGroup
+	}
+
 	if err := playTracksAndRespond(
-		m, replyMsg, r, tracks, mention,
-		isActive, opts.Force, availableSlots,
+		m, user, replyMsg, r, tracks,
+		isActive, force, availableSlots,
 	); err != nil {
 		return err
 	}
@@ -364,76 +398,83 @@ func prepareRoomAndSearchMessage(
 	return r, replyMsg, nil
 }
 
-func fetchTracksAndCheckStatus(
+func fetchTracks(
 	m *telegram.NewMessage,
 	replyMsg *telegram.NewMessage,
-	r *core.RoomState,
 	video bool,
-) ([]*state.Track, bool, error) {
+) ([]*state.Track, error) {
 	tracks, err := safeGetTracks(m, replyMsg, m.ChannelID(), video)
 	if err != nil {
 		utils.EOR(replyMsg, err.Error())
-		return nil, false, err
+		return nil, err
 	}
 
 	if len(tracks) == 0 {
 		utils.EOR(replyMsg, F(m.ChannelID(), "no_song_found"))
-		return nil, false, fmt.Errorf("no tracks found")
+		return nil, fmt.Errorf("no tracks found")
 	}
+	return tracks, nil
+}
+
+func checkVoiceChatStatus(
+	replyMsg *telegram.NewMessage,
+	r *core.RoomState,
+) (bool, error) {
+	chatID := replyMsg.ChannelID()
 
 	isActive := r.IsActiveChat()
 	cs, err := core.GetChatState(r.ChatID())
 	if err != nil {
 		gologging.ErrorF("Error getting chat state: %v", err)
-		utils.EOR(replyMsg, getErrorMessage(m.ChannelID(), err))
-		return nil, false, err
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return false, err
 	}
 
 	activeVC, err := cs.IsActiveVC()
 	if err != nil {
 		gologging.ErrorF("Error checking voicechat state: %v", err)
-		utils.EOR(replyMsg, getErrorMessage(m.ChannelID(), err))
-		return nil, false, err
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return false, err
 	}
 
 	if !activeVC {
-		utils.EOR(replyMsg, F(m.ChannelID(), "err_no_active_voicechat"))
-		return nil, false, fmt.Errorf("no active voice chat")
+		utils.EOR(replyMsg, F(chatID, "err_no_active_voicechat"))
+		return false, fmt.Errorf("no active voice chat")
 	}
 
 	banned, err := cs.IsAssistantBanned()
 	if err != nil {
 		gologging.ErrorF("Error checking assistant banned state: %v", err)
-		utils.EOR(replyMsg, getErrorMessage(m.ChannelID(), err))
-		return nil, false, err
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return false, err
 	}
 
 	if banned {
 		utils.EOR(replyMsg,
-			F(m.ChannelID(), "err_assistant_banned", locales.Arg{
+			F(chatID, "err_assistant_banned", locales.Arg{
 				"user": utils.MentionHTML(cs.Assistant.User),
 				"id":   utils.IntToStr(cs.Assistant.User.ID),
 			}),
 		)
-		return nil, false, fmt.Errorf("assistant banned")
+		return false, fmt.Errorf("assistant banned")
 	}
 
 	present, err := cs.IsAssistantPresent()
 	if err != nil {
 		gologging.ErrorF("Error checking assistant presence: %v", err)
-		utils.EOR(replyMsg, getErrorMessage(m.ChannelID(), err))
-		return nil, false, err
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return false, err
 	}
 
 	if !present {
 		if err := cs.TryJoin(); err != nil {
 			gologging.ErrorF("Error joining assistant: %v", err)
-			utils.EOR(replyMsg, getErrorMessage(m.ChannelID(), err))
-			return nil, false, err
+			utils.EOR(replyMsg, getErrorMessage(chatID, err))
+			return false, err
 		}
 		time.Sleep(1 * time.Second)
 	}
-	return tracks, isActive, nil
+	return isActive, nil
 }
 
 func filterAndTrimTracks(
@@ -513,6 +554,12 @@ func filterAndTrimTracks(
 
 	// Respect queue limit
 	availableSlots := config.QueueLimit - len(r.Queue())
+	if availableSlots <= 0 {
+		utils.EOR(replyMsg, F(chatID, "queue_limit_reached", locales.Arg{
+			"limit": config.QueueLimit,
+		}))
+		return nil, 0, fmt.Errorf("queue limit reached")
+	}
 	if availableSlots < len(tracks) {
 		tracks = tracks[:availableSlots]
 		gologging.WarnF(
@@ -524,19 +571,63 @@ func filterAndTrimTracks(
 	return tracks, availableSlots, nil
 }
 
+// applyUserQueueLimit trims tracks to the chat's per-user queue limit.
+// playsNow tells that the first track starts right away instead of
+// taking a queue slot.
+func applyUserQueueLimit(
+	replyMsg *telegram.NewMessage,
+	r *core.RoomState,
+	user *telegram.UserObj,
+	tracks []*state.Track,
+	availableSlots int,
+	playsNow bool,
+) ([]*state.Track, int, error) {
+	chatID := replyMsg.ChannelID()
+
+	// API and scheduled plays without a user are queued as the bot and
+	// aren't limited per user.
+	limit, _ := database.GetUserQueueLimit(chatID)
+	if limit <= 0 || user.ID == core.BUser.ID {
+		return tracks, availableSlots, nil
+	}
+
+	allowed := limit - r.QueuedBy(user.ID)
+	if playsNow {
+		allowed++
+	}
+	if allowed <= 0 {
+		utils.EOR(replyMsg, F(chatID, "user_queue_limit_reached", locales.Arg{
+			"limit": limit,
+		}))
+		return nil, 0, fmt.Errorf("user queue limit reached")
+	}
+	if allowed < len(tracks) {
+		gologging.WarnF(
+			"User queue limit — adding only %d tracks out of requested.",
+			allowed,
+		)
+		tracks = tracks[:allowed]
+		availableSlots = min(availableSlots, allowed)
+	}
+
+	return tracks, availableSlots, nil
+}
+
 func playTracksAndRespond(
 	m *telegram.NewMessage,
+	user *telegram.UserObj,
 	replyMsg *telegram.NewMessage,
 	r *core.RoomState,
 	tracks []*state.Track,
-	mention string,
 	isActive, force bool,
 	availableSlots int,
 ) error {
 	chatID := m.ChannelID()
+	mention := utils.MentionHTML(user)
 
 	for i, track := range tracks {
 		track.Requester = mention
+		track.RequesterID = user.ID
 		title := html.EscapeString(utils.ShortTitle(track.Title, 25))
 		var filePath string
 
@@ -590,7 +681,7 @@ func playTracksAndRespond(
 			return err
 		}
 
-		sendPlayLogs(m, track, (isActive && !force) || i > 0)
+		sendPlayLogs(m, user, track, (isActive && !force) || i > 0)
 	}
 
 	mainTrack := tracks[0]
@@ -0,0 +1,506 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+const (
+	maxPlaylists      = 20
+	maxPlaylistTracks = 100
+)
+
+var playlistNameRe = regexp.MustCompile(`^[\p{L}\p{N}_-]{1,32}$`)
+
+func init() {
+	helpTexts["/playlist"] = `<i>Save tracks into named playlists and play them any time.</i>
+
+<u>Usage:</u>
+<b>/playlist</b> — List your and this chat's playlists
+<b>/playlist create &lt;name&gt;</b> — Create a playlist
+<b>/playlist add &lt;name&gt; [query/URL]</b> — Add a track, or the current one
+<b>/playlist remove &lt;name&gt; &lt;index&gt;</b> — Remove a track
+<b>/playlist show &lt;name&gt;</b> — List the tracks
+<b>/playlist play &lt;name&gt;</b> — Queue the whole playlist
+<b>/playlist delete &lt;name&gt;</b> — Delete a playlist
+
+<b>⚙️ Features:</b>
+• Add <code>--chat</code> to use the playlist shared with this chat
+• Chat playlists can only be changed by admins and auth users
+• <b>show</b> and <b>play</b> fall back to the chat playlist if you have none with that name
+• Tracks are stored resolved, so playing them doesn't search again
+
+<b>⚠️ Limits:</b>
+• Up to ` + strconv.Itoa(maxPlaylists) + ` playlists, ` + strconv.Itoa(maxPlaylistTracks) + ` tracks each
+
+<b>💡 Examples:</b>
+<code>/playlist create chill</code>
+<code>/playlist add chill lofi hip hop</code>
+<code>/playlist play chill</code>`
+}
+
+type playlistArgs struct {
+	sub    string
+	name   string
+	rest   string
+	shared bool
+}
+
+func parsePlaylistArgs(m *tg.NewMessage) playlistArgs {
+	var a playlistArgs
+	var rest []string
+	for _, f := range strings.Fields(m.Args()) {
+		if strings.EqualFold(f, "--chat") {
+			a.shared = true
+			continue
+		}
+		switch {
+		case a.sub == "":
+			a.sub = strings.ToLower(f)
+		case a.name == "":
+			a.name = f
+		default:
+			rest = append(rest, f)
+		}
+	}
+	a.rest = strings.Join(rest, " ")
+	return a
+}
+
+func playlistHandler(m *tg.NewMessage) error {
+	a := parsePlaylistArgs(m)
+
+	switch a.sub {
+	case "", "list":
+		return listPlaylists(m)
+	case "create", "add", "remove", "show", "play", "delete":
+	default:
+		m.Reply(F(m.ChannelID(), "playlist_usage"))
+		return tg.ErrEndGroup
+	}
+
+	if a.name == "" {
+		m.Reply(F(m.ChannelID(), "playlist_usage"))
+		return tg.ErrEndGroup
+	}
+
+	switch a.sub {
+	case "create":
+		return createPlaylist(m, a)
+	case "add":
+		return addToPlaylist(m, a)
+	case "remove":
+		return removeFromPlaylist(m, a)
+	case "show":
+		return showPlaylist(m, a)
+	case "play":
+		return playPlaylist(m, a)
+	default:
+		return deletePlaylist(m, a)
+	}
+}
+
+// playlistOwner returns whose playlists a command works on. Changing a
+// chat playlist needs admin or auth rights.
+func playlistOwner(m *tg.NewMessage, a playlistArgs, modify bool) (int64, bool) {
+	if !a.shared {
+		return m.SenderID(), true
+	}
+	if modify && !filterAuthUsers(m) {
+		return 0, false
+	}
+	return m.ChannelID(), true
+}
+
+// findPlaylist loads a playlist for reading. Without --chat the user's
+// own playlist wins, then the chat's.
+func findPlaylist(m *tg.NewMessage, a playlistArgs) (*database.Playlist, error) {
+	if a.shared {
+		return database.GetPlaylist(m.ChannelID(), a.name)
+	}
+	p, err := database.GetPlaylist(m.SenderID(), a.name)
+	if err == database.ErrNotFound {
+		return database.GetPlaylist(m.ChannelID(), a.name)
+	}
+	return p, err
+}
+
+func loadPlaylist(m *tg.NewMessage, ownerID int64, name string) *database.Playlist {
+	chatID := m.ChannelID()
+	p, err := database.GetPlaylist(ownerID, name)
+	if err == database.ErrNotFound {
+		m.Reply(F(chatID, "playlist_not_found", locales.Arg{
+			"name": html.EscapeString(name),
+		}))
+		return nil
+	} else if err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return nil
+	}
+	return p
+}
+
+func listPlaylists(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+
+	own, err1 := database.GetPlaylists(m.SenderID())
+	shared, err2 := database.GetPlaylists(chatID)
+	if err1 != nil || err2 != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+	if len(own) == 0 && len(shared) == 0 {
+		m.Reply(F(chatID, "playlist_none"))
+		return tg.ErrEndGroup
+	}
+
+	var b strings.Builder
+	writeList := func(header string, lists []*database.Playlist) {
+		if len(lists) == 0 {
+			return
+		}
+		b.WriteString(header + "\n")
+		for _, p := range lists {
+			b.WriteString(fmt.Sprintf(
+				"• <code>%s</code> — %d\n",
+				html.EscapeString(p.Name),
+				len(p.Tracks),
+			))
+		}
+		b.WriteString("\n")
+	}
+	writeList(F(chatID, "playlist_list_own"), own)
+	writeList(F(chatID, "playlist_list_chat"), shared)
+	b.WriteString(F(chatID, "playlist_list_footer"))
+
+	m.Reply(b.String())
+	return tg.ErrEndGroup
+}
+
+func createPlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	if !playlistNameRe.MatchString(a.name) {
+		m.Reply(F(chatID, "playlist_invalid_name"))
+		return tg.ErrEndGroup
+	}
+
+	ownerID, ok := playlistOwner(m, a, true)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+
+	if _, err := database.GetPlaylist(ownerID, a.name); err == nil {
+		m.Reply(F(chatID, "playlist_exists", locales.Arg{
+			"name": html.EscapeString(a.name),
+		}))
+		return tg.ErrEndGroup
+	} else if err != database.ErrNotFound {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	lists, err := database.GetPlaylists(ownerID)
+	if err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+	if len(lists) >= maxPlaylists {
+		m.Reply(F(chatID, "playlist_too_many", locales.Arg{
+			"limit": maxPlaylists,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	p := &database.Playlist{
+		OwnerID:   ownerID,
+		Shared:    a.shared,
+		Name:      a.name,
+		CreatedBy: m.SenderID(),
+	}
+	if err := database.SavePlaylist(p); err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "playlist_created", locales.Arg{
+		"name": html.EscapeString(a.name),
+	}))
+	return tg.ErrEndGroup
+}
+
+func addToPlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	ownerID, ok := playlistOwner(m, a, true)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+	p := loadPlaylist(m, ownerID, a.name)
+	if p == nil {
+		return tg.ErrEndGroup
+	}
+
+	if len(p.Tracks) >= maxPlaylistTracks {
+		m.Reply(F(chatID, "playlist_full", locales.Arg{
+			"limit": maxPlaylistTracks,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	var tracks []*state.Track
+	if a.rest == "" {
+		r, ok := core.GetRoom(chatID, nil)
+		if !ok || r.Track() == nil {
+			m.Reply(F(chatID, "playlist_add_nothing"))
+			return tg.ErrEndGroup
+		}
+		tracks = []*state.Track{r.Track()}
+	} else {
+		replyMsg, err := m.Reply(F(chatID, "searching_query", locales.Arg{
+			"query": html.EscapeString(a.rest),
+		}))
+		if err != nil {
+			return tg.ErrEndGroup
+		}
+
+		tracks, err = platforms.GetTracksByQuery(a.rest, false)
+		if err != nil || len(tracks) == 0 {
+			utils.EOR(replyMsg, F(chatID, "no_song_found"))
+			return tg.ErrEndGroup
+		}
+		replyMsg.Delete()
+	}
+
+	added := 0
+	for _, t := range tracks {
+		if len(p.Tracks) >= maxPlaylistTracks {
+			break
+		}
+		if t.IsLive {
+			continue
+		}
+		p.Tracks = append(p.Tracks, playlistTrack(t))
+		added++
+	}
+
+	if added == 0 {
+		m.Reply(F(chatID, "playlist_add_nothing"))
+		return tg.ErrEndGroup
+	}
+	if err := database.SavePlaylist(p); err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	if added == 1 {
+		t := p.Tracks[len(p.Tracks)-1]
+		m.Reply(F(chatID, "playlist_added", locales.Arg{
+			"url":   t.URL,
+			"title": html.EscapeString(utils.ShortTitle(t.Title, 35)),
+			"name":  html.EscapeString(p.Name),
+			"total": len(p.Tracks),
+		}))
+	} else {
+		m.Reply(F(chatID, "playlist_added_many", locales.Arg{
+			"count": added,
+			"name":  html.EscapeString(p.Name),
+			"total": len(p.Tracks),
+		}))
+	}
+	return tg.ErrEndGroup
+}
+
+// playlistTrack keeps only what's needed to download a track again.
+func playlistTrack(t *state.Track) *state.Track {
+	return &state.Track{
+		ID:       t.ID,
+		Title:    t.Title,
+		Duration: t.Duration,
+		Artwork:  t.Artwork,
+		URL:      t.URL,
+		Video:    t.Video,
+		Source:   t.Source,
+	}
+}
+
+func removeFromPlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	ownerID, ok := playlistOwner(m, a, true)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+	p := loadPlaylist(m, ownerID, a.name)
+	if p == nil {
+		return tg.ErrEndGroup
+	}
+
+	idx, err := strconv.Atoi(a.rest)
+	if err != nil || idx < 1 || idx > len(p.Tracks) {
+		m.Reply(F(chatID, "playlist_invalid_index", locales.Arg{
+			"total": len(p.Tracks),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	t := p.Tracks[idx-1]
+	p.Tracks = append(p.Tracks[:idx-1], p.Tracks[idx:]...)
+	if err := database.SavePlaylist(p); err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "playlist_removed", locales.Arg{
+		"url":   t.URL,
+		"title": html.EscapeString(utils.ShortTitle(t.Title, 35)),
+		"name":  html.EscapeString(p.Name),
+	}))
+	return tg.ErrEndGroup
+}
+
+func showPlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	p, err := findPlaylist(m, a)
+	if err == database.ErrNotFound {
+		m.Reply(F(chatID, "playlist_not_found", locales.Arg{
+			"name": html.EscapeString(a.name),
+		}))
+		return tg.ErrEndGroup
+	} else if err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	var b strings.Builder
+	b.WriteString(F(chatID, "playlist_show_header", locales.Arg{
+		"name":  html.EscapeString(p.Name),
+		"count": len(p.Tracks),
+	}))
+	b.WriteString("\n\n")
+
+	if len(p.Tracks) == 0 {
+		b.WriteString(F(chatID, "playlist_empty"))
+	}
+
+	total := 0
+	for i, t := range p.Tracks {
+		total += t.Duration
+		b.WriteString(fmt.Sprintf(
+			"%d. <a href=\"%s\">%s</a> [%s]\n",
+			i+1,
+			t.URL,
+			html.EscapeString(utils.ShortTitle(t.Title, 35)),
+			formatDuration(t.Duration),
+		))
+	}
+	if total > 0 {
+		b.WriteString("\n" + F(chatID, "playlist_show_footer", locales.Arg{
+			"duration": formatDuration(total),
+		}))
+	}
+
+	m.Reply(b.String(), &tg.SendOptions{
+		ParseMode:   "HTML",
+		LinkPreview: false,
+	})
+	return tg.ErrEndGroup
+}
+
+func playPlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	p, err := findPlaylist(m, a)
+	if err == database.ErrNotFound {
+		m.Reply(F(chatID, "playlist_not_found", locales.Arg{
+			"name": html.EscapeString(a.name),
+		}))
+		return tg.ErrEndGroup
+	} else if err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+	if len(p.Tracks) == 0 {
+		m.Reply(F(chatID, "playlist_empty"))
+		return tg.ErrEndGroup
+	}
+
+	r, err := getEffectiveRoom(m, false)
+	if err != nil {
+		m.Reply(err.Error())
+		return tg.ErrEndGroup
+	}
+	r.SetCPlay(false)
+
+	replyMsg, err := m.Reply(F(chatID, "playlist_loading", locales.Arg{
+		"name":  html.EscapeString(p.Name),
+		"count": len(p.Tracks),
+	}))
+	if err != nil {
+		return tg.ErrEndGroup
+	}
+
+	// copies, playTracksAndRespond sets the requester on them
+	tracks := make([]*state.Track, len(p.Tracks))
+	for i, t := range p.Tracks {
+		c := *t
+		tracks[i] = &c
+	}
+	return playResolvedTracks(m, m.Sender, replyMsg, r, tracks, false)
+}
+
+func deletePlaylist(m *tg.NewMessage, a playlistArgs) error {
+	chatID := m.ChannelID()
+
+	ownerID, ok := playlistOwner(m, a, true)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+	p := loadPlaylist(m, ownerID, a.name)
+	if p == nil {
+		return tg.ErrEndGroup
+	}
+
+	if err := database.DeletePlaylist(ownerID, p.Name); err != nil {
+		m.Reply(F(chatID, "playlist_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "playlist_deleted", locales.Arg{
+		"name": html.EscapeString(p.Name),
+	}))
+	return tg.ErrEndGroup
+}
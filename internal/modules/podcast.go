@@ -0,0 +1,320 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+const (
+	// podcastPageSize is how many episodes one page of the picker shows.
+	podcastPageSize = 8
+	// podcastPickTTL is how long the buttons of a picker stay usable.
+	podcastPickTTL = 10 * time.Minute
+	// podcastResumeMin is the least progress worth remembering, and how
+	// close to the end an episode counts as finished.
+	podcastResumeMin = 60
+)
+
+var podcastPicks = utils.NewCache[string, *picker](podcastPickTTL) // chat:message -> episodes
+
+// podcastQuery splits the arguments of /play into a feed url and what
+// follows it, if they start with a podcast feed.
+func podcastQuery(args string) (feedURL, rest string, ok bool) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	p := platforms.FindPlatform(fields[0])
+	if p == nil || p.Name() != platforms.PlatformPodcast {
+		return "", "", false
+	}
+	return fields[0], strings.Join(fields[1:], " "), true
+}
+
+// handlePodcastPlay plays the episode of feedURL that arg asks for:
+// "latest", its number (1 is the newest) or a search in the titles.
+// Without arg, or when the search matches several, a picker is shown.
+func handlePodcastPlay(
+	m *tg.NewMessage,
+	replyMsg *tg.NewMessage,
+	r *core.RoomState,
+	feedURL, arg string,
+	opts *playOpts,
+) error {
+	chatID := m.ChannelID()
+
+	feed, err := platforms.GetPodcastFeed(feedURL)
+	if err != nil {
+		utils.EOR(replyMsg, F(chatID, "podcast_feed_failed", locales.Arg{
+			"error": html.EscapeString(err.Error()),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	episodes := feed.Episodes
+	switch n, err := strconv.Atoi(arg); {
+	case strings.EqualFold(arg, "latest"):
+		episodes = episodes[:1]
+	case err == nil:
+		if n < 1 || n > len(episodes) {
+			utils.EOR(replyMsg, F(chatID, "podcast_no_episode", locales.Arg{
+				"count": len(episodes),
+			}))
+			return tg.ErrEndGroup
+		}
+		episodes = episodes[n-1 : n]
+	case arg != "":
+		episodes = filterEpisodes(episodes, arg)
+		if len(episodes) == 0 {
+			utils.EOR(replyMsg, F(chatID, "podcast_no_match", locales.Arg{
+				"query": html.EscapeString(arg),
+			}))
+			return tg.ErrEndGroup
+		}
+	}
+
+	if len(episodes) == 1 {
+		return playResolvedTracks(
+			m, m.Sender, replyMsg, r, []*state.Track{copyEpisode(episodes[0])}, opts.Force,
+		)
+	}
+
+	pick := &picker{
+		title:  feed.Title,
+		tracks: episodes,
+		userID: m.SenderID(),
+		cplay:  opts.CPlay,
+		force:  opts.Force,
+	}
+	text, markup := buildPodcastPage(chatID, pick, 0)
+	replyMsg, err = utils.EOR(replyMsg, text, &tg.SendOptions{
+		ParseMode:   "HTML",
+		ReplyMarkup: markup,
+	})
+	if err != nil || replyMsg == nil {
+		return tg.ErrEndGroup
+	}
+
+	podcastPicks.Set(pickKey(chatID, replyMsg.ID), pick)
+	return tg.ErrEndGroup
+}
+
+func filterEpisodes(episodes []*state.Track, query string) []*state.Track {
+	query = strings.ToLower(query)
+	var matched []*state.Track
+	for _, e := range episodes {
+		if strings.Contains(strings.ToLower(e.Title), query) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// copyEpisode returns a copy of a cached episode, so that queueing it
+// doesn't change the feed cache.
+func copyEpisode(t *state.Track) *state.Track {
+	c := *t
+	return &c
+}
+
+func buildPodcastPage(
+	chatID int64,
+	pick *picker,
+	page int,
+) (string, tg.ReplyMarkup) {
+	pages := (len(pick.tracks) + podcastPageSize - 1) / podcastPageSize
+	if page < 0 {
+		page = 0
+	} else if page >= pages {
+		page = pages - 1
+	}
+
+	start := page * podcastPageSize
+	end := min(start+podcastPageSize, len(pick.tracks))
+
+	kb := tg.NewKeyboard()
+	for i := start; i < end; i++ {
+		kb.AddRow(tg.Button.Data(
+			episodeLabel(i+1, pick.tracks[i]),
+			"podcast:e:"+strconv.Itoa(i),
+		))
+	}
+
+	var nav []tg.KeyboardButton
+	if page > 0 {
+		nav = append(nav, tg.Button.Data("◀", "podcast:p:"+strconv.Itoa(page-1)))
+	}
+	nav = append(nav, tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"))
+	if page < pages-1 {
+		nav = append(nav, tg.Button.Data("▶", "podcast:p:"+strconv.Itoa(page+1)))
+	}
+	kb.AddRow(nav...)
+
+	text := F(chatID, "podcast_episodes", locales.Arg{
+		"title": html.EscapeString(pick.title),
+		"count": len(pick.tracks),
+		"page":  page + 1,
+		"pages": pages,
+	})
+	return text, kb.Build()
+}
+
+// episodeLabel is the button text of an episode, e.g.
+// "3. The Long Interview · 1:12:05".
+func episodeLabel(n int, t *state.Track) string {
+	label := strconv.Itoa(n) + ". " + utils.ShortTitle(t.Title, 35)
+	if t.Duration > 0 {
+		label += " · " + formatDuration(t.Duration)
+	}
+	return label
+}
+
+func podcastCB(cb *tg.CallbackQuery) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	// podcast:<e|p>:<value>
+	parts := strings.Split(cb.DataString(), ":")
+	if len(parts) != 3 {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+	value, err := strconv.Atoi(parts[2])
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	pick, ok := getPicker(cb, podcastPicks, "podcast")
+	if !ok {
+		return tg.ErrEndGroup
+	}
+
+	switch parts[1] {
+	case "p":
+		text, markup := buildPodcastPage(chatID, pick, value)
+		cb.Answer("")
+		cb.Edit(text, &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: markup})
+		return tg.ErrEndGroup
+	case "e":
+		return playPicked(cb, podcastPicks, pick, value, "podcast", func(t *state.Track) string {
+			return F(chatID, "searching_query", locales.Arg{
+				"query": html.EscapeString(t.Title),
+			})
+		})
+	default:
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+}
+
+// MonitorPodcasts remembers how far each user got into a podcast episode
+// and continues from there the next time they play it.
+func MonitorPodcasts() {
+	events, _ := core.Subscribe(0, 64)
+
+	for e := range events {
+		t := e.Track
+		if t == nil || t.Source != platforms.PlatformPodcast || t.RequesterID == 0 {
+			continue
+		}
+
+		switch e.Type {
+		case core.EventTrackStarted:
+			// restored rooms already continue where they were
+			if e.Position == 0 {
+				resumePodcast(e.ChatID, t)
+			}
+		case core.EventTrackEnded, core.EventPaused:
+			savePodcastPosition(t, e.Position, e.Reason)
+		}
+	}
+}
+
+func savePodcastPosition(t *state.Track, pos int, reason state.EndReason) {
+	finished := reason == state.EndFinished ||
+		(t.Duration > 0 && t.Duration-pos <= podcastResumeMin)
+
+	var err error
+	switch {
+	case finished:
+		err = database.DeletePodcastResume(t.RequesterID, t.ID)
+	case pos >= podcastResumeMin:
+		err = database.SetPodcastResume(t.RequesterID, t.ID, pos)
+	}
+	if err != nil {
+		gologging.ErrorF("Failed to save podcast position of %d: %v", t.RequesterID, err)
+	}
+}
+
+// resumePodcast seeks the room to where the requester of t stopped it
+// last time.
+func resumePodcast(roomID int64, t *state.Track) {
+	pos, err := database.GetPodcastResume(t.RequesterID, t.ID)
+	if err != nil {
+		gologging.ErrorF("Failed to load podcast position of %d: %v", t.RequesterID, err)
+		return
+	}
+	if pos < podcastResumeMin || t.Duration <= 0 || pos >= t.Duration-podcastResumeMin {
+		return
+	}
+
+	r, ok := core.GetRoom(roomID, nil)
+	if !ok {
+		return
+	}
+	if cur := r.Track(); cur == nil || cur.ID != t.ID {
+		return
+	}
+	if err := r.Seek(pos - r.Position()); err != nil {
+		gologging.DebugF("Failed to resume podcast in %d: %v", roomID, err)
+		return
+	}
+
+	chatID := roomID
+	if r.IsCPlay() {
+		cid, err := database.GetChatIDFromCPlayID(roomID)
+		if err != nil {
+			return
+		}
+		chatID = cid
+	}
+
+	if _, err := core.Bot.SendMessage(chatID, F(chatID, "podcast_resumed", locales.Arg{
+		"position": formatDuration(pos),
+	})); err != nil {
+		gologging.ErrorF("Failed to announce podcast resume in %d: %v", chatID, err)
+	}
+}
@@ -0,0 +1,317 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/radio"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/radio"] = `<i>Find an internet radio station and play it.</i>
+
+<u>Usage:</u>
+<b>/radio &lt;search&gt;</b> — Search stations by name, genre or country
+
+<b>⚙️ Behavior:</b>
+• Stations from the bot's curated list come first, then Radio Browser
+• Tap a station to play it, only the one who searched can pick
+• The station plays live until stopped or skipped
+• For Icecast/SHOUTcast stations the now playing message shows the song on air
+
+<b>💡 Examples:</b>
+<code>/radio lofi</code>
+<code>/radio bbc radio 1</code>`
+}
+
+const (
+	// radioResults is how many stations the picker offers.
+	radioResults = 8
+	// radioPickTTL is how long the buttons of a picker stay usable.
+	radioPickTTL = 5 * time.Minute
+	// icyRetryDelay spaces out reconnects of the metadata reader.
+	icyRetryDelay = 15 * time.Second
+)
+
+type icyWatch struct {
+	cancel  context.CancelFunc
+	trackID string
+}
+
+var (
+	radioPicks = utils.NewCache[string, *picker](radioPickTTL) // chat:message -> stations
+
+	icyWatches   = make(map[int64]*icyWatch) // room ID -> metadata reader
+	icyWatchesMu sync.Mutex
+)
+
+func radioHandler(m *tg.NewMessage) error {
+	return handleRadio(m, false)
+}
+
+func cradioHandler(m *tg.NewMessage) error {
+	return handleRadio(m, true)
+}
+
+func handleRadio(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	query := strings.TrimSpace(m.Args())
+	if query == "" {
+		m.Reply(F(chatID, "radio_usage", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if cplay {
+		if cplayID, err := database.GetCPlayID(chatID); err != nil || cplayID == 0 {
+			m.Reply(F(chatID, "cplay_id_not_set"))
+			return tg.ErrEndGroup
+		}
+	}
+
+	replyMsg, err := m.Reply(F(chatID, "radio_searching", locales.Arg{
+		"query": html.EscapeString(query),
+	}))
+	if err != nil {
+		return tg.ErrEndGroup
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	stations, err := radio.Search(ctx, query, radioResults)
+	if err != nil {
+		utils.EOR(replyMsg, F(chatID, "radio_not_found", locales.Arg{
+			"query": html.EscapeString(query),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	kb := tg.NewKeyboard()
+	for i, s := range stations {
+		kb.AddRow(tg.Button.Data(stationLabel(s), "radio:"+strconv.Itoa(i)))
+	}
+	kb.AddRow(tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"))
+
+	replyMsg, err = utils.EOR(replyMsg, F(chatID, "radio_results", locales.Arg{
+		"query": html.EscapeString(query),
+		"count": len(stations),
+	}), &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: kb.Build()})
+	if err != nil || replyMsg == nil {
+		return tg.ErrEndGroup
+	}
+
+	tracks := make([]*state.Track, len(stations))
+	for i, s := range stations {
+		tracks[i] = stationTrack(s)
+	}
+	radioPicks.Set(pickKey(chatID, replyMsg.ID), &picker{
+		tracks: tracks,
+		userID: m.SenderID(),
+		cplay:  cplay,
+	})
+	return tg.ErrEndGroup
+}
+
+// stationLabel is the button text of a station, e.g.
+// "BBC Radio 1 · GB · 128k".
+func stationLabel(s *state.RadioStation) string {
+	label := utils.ShortTitle(s.Name, 35)
+	if s.Country != "" {
+		label += " · " + s.Country
+	}
+	if s.Bitrate > 0 {
+		label += " · " + strconv.Itoa(s.Bitrate) + "k"
+	}
+	return label
+}
+
+func radioCB(cb *tg.CallbackQuery) error {
+	chatID := cb.ChannelID()
+
+	// radio:<index>
+	idx, err := strconv.Atoi(strings.TrimPrefix(cb.DataString(), "radio:"))
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), &tg.CallbackOptions{Alert: true})
+		return tg.ErrEndGroup
+	}
+
+	pick, ok := getPicker(cb, radioPicks, "radio")
+	if !ok {
+		return tg.ErrEndGroup
+	}
+	return playPicked(cb, radioPicks, pick, idx, "radio", func(t *state.Track) string {
+		return F(chatID, "radio_tuning", locales.Arg{
+			"station": html.EscapeString(t.Title),
+		})
+	})
+}
+
+// stationTrack turns a station into a live track played straight from its
+// stream url.
+func stationTrack(s *state.RadioStation) *state.Track {
+	return &state.Track{
+		ID:     "radio_" + s.ID,
+		Title:  s.Name,
+		URL:    s.URL,
+		Source: platforms.PlatformDirectStream,
+		IsLive: true,
+	}
+}
+
+// MonitorRadio reads the ICY metadata of the live radio stations being
+// played and shows the song on air in their now playing message.
+func MonitorRadio() {
+	events, _ := core.Subscribe(0, 64)
+
+	for e := range events {
+		switch e.Type {
+		case core.EventTrackStarted:
+			if t := e.Track; t != nil && t.IsLive &&
+				t.Source == platforms.PlatformDirectStream {
+				startICYWatch(e.ChatID, t)
+			} else {
+				stopICYWatch(e.ChatID)
+			}
+		case core.EventTrackEnded, core.EventRoomDestroyed:
+			stopICYWatch(e.ChatID)
+		}
+	}
+}
+
+func startICYWatch(roomID int64, t *state.Track) {
+	icyWatchesMu.Lock()
+	defer icyWatchesMu.Unlock()
+
+	if w, ok := icyWatches[roomID]; ok {
+		if w.trackID == t.ID {
+			return
+		}
+		w.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &icyWatch{cancel: cancel, trackID: t.ID}
+	icyWatches[roomID] = w
+
+	go func() {
+		defer func() {
+			icyWatchesMu.Lock()
+			if icyWatches[roomID] == w {
+				delete(icyWatches, roomID)
+			}
+			icyWatchesMu.Unlock()
+			cancel()
+		}()
+		runICYWatch(ctx, roomID, t)
+	}()
+}
+
+func stopICYWatch(roomID int64) {
+	icyWatchesMu.Lock()
+	defer icyWatchesMu.Unlock()
+
+	if w, ok := icyWatches[roomID]; ok {
+		w.cancel()
+		delete(icyWatches, roomID)
+	}
+}
+
+// runICYWatch keeps a metadata reader connected to the station of t until
+// ctx is cancelled. Streams without metadata are given up on at once.
+func runICYWatch(ctx context.Context, roomID int64, t *state.Track) {
+	for {
+		err := radio.WatchICY(ctx, t.URL, func(title string) {
+			showOnAir(roomID, t.ID, title)
+		})
+		if ctx.Err() != nil || errors.Is(err, radio.ErrNoMetadata) {
+			return
+		}
+		gologging.DebugF("ICY metadata of %s in %d stopped: %v", t.URL, roomID, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(icyRetryDelay):
+		}
+	}
+}
+
+// showOnAir adds the song on air to the now playing message of the room,
+// if track trackID still plays there.
+func showOnAir(roomID int64, trackID, song string) {
+	r, ok := core.GetRoom(roomID, nil)
+	if !ok {
+		return
+	}
+	t := r.Track()
+	mystic := r.GetMystic()
+	if t == nil || t.ID != trackID || mystic == nil {
+		return
+	}
+
+	chatID := roomID
+	if r.IsCPlay() {
+		cid, err := database.GetChatIDFromCPlayID(roomID)
+		if err != nil {
+			return
+		}
+		chatID = cid
+	}
+
+	text := F(chatID, "stream_now_playing", locales.Arg{
+		"url":      t.URL,
+		"title":    html.EscapeString(utils.ShortTitle(t.Title, 25)),
+		"duration": formatDuration(t.Duration),
+		"by":       t.Requester,
+	}) + F(chatID, "radio_on_air", locales.Arg{
+		"title": html.EscapeString(song),
+	})
+
+	edited, err := mystic.Edit(text, &tg.SendOptions{
+		ParseMode:   "HTML",
+		ReplyMarkup: core.GetPlayMarkup(chatID, r, false),
+	})
+	if err != nil {
+		if !tg.MatchError(err, "MESSAGE_NOT_MODIFIED") {
+			gologging.DebugF("Failed to show song on air in %d: %v", chatID, err)
+		}
+		return
+	}
+	r.UpdateMystic(edited)
+}
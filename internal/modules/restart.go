@@ -84,6 +84,7 @@ func handleRestart(m *tg.NewMessage) error {
 		}
 
 		if r, _ := core.GetRoom(id, ass); r != nil {
+			r.SaveSnapshot()
 			r.Stop()
 			m.Client.SendMessage(id, F(id, "restart_service", locales.Arg{
 				"bot": utils.MentionHTML(core.BUser),
@@ -0,0 +1,236 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/config"
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+// snapshots older than this are considered stale and are not offered again
+const restoreMaxAge = 6 * time.Hour
+
+var pendingRestores = utils.NewCache[int64, *state.RoomSnapshot](30 * time.Minute)
+
+func restoreRooms() {
+	if config.ResumeMode == "off" {
+		return
+	}
+
+	snaps, err := database.GetRoomSnapshots()
+	if err != nil {
+		gologging.ErrorF("Failed to load room snapshots: %v", err)
+		return
+	}
+
+	for _, snap := range snaps {
+		age := time.Since(time.Unix(snap.UpdatedAt, 0))
+		if snap.Track == nil || age > restoreMaxAge {
+			database.DeleteRoomSnapshot(snap.ChatID)
+			continue
+		}
+
+		chatID := snap.ChatID
+		if snap.CPlay {
+			cid, err := database.GetChatIDFromCPlayID(snap.ChatID)
+			if err != nil {
+				database.DeleteRoomSnapshot(snap.ChatID)
+				continue
+			}
+			chatID = cid
+		}
+
+		if config.ResumeMode == "auto" {
+			if err := restoreRoom(snap, chatID); err != nil {
+				gologging.ErrorF("Failed to restore room %d: %v", snap.ChatID, err)
+			}
+			continue
+		}
+
+		pendingRestores.Set(snap.ChatID, snap)
+		_, err := core.Bot.SendMessage(chatID, F(chatID, "restore_prompt", locales.Arg{
+			"url":      snap.Track.URL,
+			"title":    html.EscapeString(utils.ShortTitle(snap.Track.Title, 25)),
+			"position": formatDuration(snap.Position),
+			"count":    len(snap.Queue),
+		}), &tg.SendOptions{
+			ParseMode:   "HTML",
+			ReplyMarkup: core.GetRestoreMarkup(chatID, snap.ChatID),
+		})
+		if err != nil {
+			gologging.ErrorF("Failed to send restore prompt to %d: %v", chatID, err)
+		}
+	}
+}
+
+// restoreRoom rejoins the voice chat and continues the snapshot's track
+// from its saved position. Messages are sent to chatID.
+func restoreRoom(snap *state.RoomSnapshot, chatID int64) error {
+	ass, err := core.Assistants.ForChat(snap.ChatID)
+	if err != nil {
+		return err
+	}
+
+	cs, err := core.GetChatState(snap.ChatID)
+	if err != nil {
+		return err
+	}
+
+	if active, err := cs.IsActiveVC(true); err != nil {
+		return err
+	} else if !active {
+		database.DeleteRoomSnapshot(snap.ChatID)
+		return fmt.Errorf("no active voice chat")
+	}
+
+	if present, err := cs.IsAssistantPresent(); err != nil {
+		return err
+	} else if !present {
+		if err := cs.TryJoin(); err != nil {
+			return err
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	r, _ := core.GetRoom(snap.ChatID, ass, true)
+	r.Restore(snap)
+
+	t := snap.Track
+	mystic, err := core.Bot.SendMessage(chatID, F(chatID, "restore_resuming"))
+	if err != nil {
+		gologging.ErrorF("[restore.go] Failed to send msg: %v", err)
+	}
+
+	path, err := platforms.Download(context.Background(), t, mystic)
+	if err != nil {
+		utils.EOR(mystic, F(chatID, "stream_download_fail", locales.Arg{
+			"error": err.Error(),
+		}))
+		r.Destroy()
+		return err
+	}
+
+	if err := r.Play(t, path, true); err != nil {
+		utils.EOR(mystic, F(chatID, "stream_play_fail"))
+		r.Destroy()
+		return err
+	}
+
+	if snap.Position > 0 {
+		if err := r.Seek(snap.Position); err != nil {
+			gologging.WarnF("Failed to seek restored room %d: %v", snap.ChatID, err)
+		}
+	}
+
+	msgText := F(chatID, "stream_now_playing", locales.Arg{
+		"url":      t.URL,
+		"title":    html.EscapeString(utils.ShortTitle(t.Title, 25)),
+		"duration": formatDuration(t.Duration),
+		"by":       t.Requester,
+	})
+
+	opt := &tg.SendOptions{
+		ParseMode:   "HTML",
+		ReplyMarkup: core.GetPlayMarkup(chatID, r, false),
+	}
+	if t.Artwork != "" {
+		opt.Media = utils.CleanURL(t.Artwork)
+	}
+
+	mystic, _ = utils.EOR(mystic, msgText, opt)
+	r.SetMystic(mystic)
+	return nil
+}
+
+func restoreCB(cb *tg.CallbackQuery) error {
+	opt := &tg.CallbackOptions{Alert: true}
+	chatID := cb.ChannelID()
+
+	// restore:<yes|no>:<roomID>
+	parts := strings.Split(cb.DataString(), ":")
+	if len(parts) != 3 {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	roomID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || !isOwnRoomID(chatID, roomID) {
+		cb.Answer(F(chatID, "invalid_request"), opt)
+		return tg.ErrEndGroup
+	}
+
+	if !checkAdminOrAuth(cb, chatID, opt) {
+		return tg.ErrEndGroup
+	}
+
+	snap, ok := pendingRestores.Get(roomID)
+	if !ok {
+		cb.Answer(F(chatID, "restore_expired"), opt)
+		cb.Delete()
+		return tg.ErrEndGroup
+	}
+	pendingRestores.Delete(roomID)
+
+	if parts[1] != "yes" {
+		database.DeleteRoomSnapshot(roomID)
+		cb.Answer("")
+		editMessage(cb, F(chatID, "restore_dismissed", locales.Arg{
+			"user": utils.MentionHTML(cb.Sender),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	cb.Answer("")
+	cb.Delete()
+
+	if err := restoreRoom(snap, chatID); err != nil {
+		gologging.ErrorF("Failed to restore room %d: %v", roomID, err)
+		core.Bot.SendMessage(chatID, F(chatID, "restore_failed", locales.Arg{
+			"error": html.EscapeString(err.Error()),
+		}))
+	}
+	return tg.ErrEndGroup
+}
+
+// isOwnRoomID reports whether roomID is chatID's own room or the channel
+// it plays in, the only rooms its admins may restore.
+func isOwnRoomID(chatID, roomID int64) bool {
+	if roomID == chatID {
+		return true
+	}
+	cplayID, err := database.GetCPlayID(chatID)
+	return err == nil && cplayID != 0 && cplayID == roomID
+}
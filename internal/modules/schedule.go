@@ -0,0 +1,567 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/config"
+	"main/internal/core"
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/schedule"] = `<i>Play something at a set time, once or every week.</i>
+
+<u>Usage:</u>
+<b>/schedule &lt;time&gt; [play|force] &lt;query&gt;</b> — Once
+<b>/schedule every &lt;days&gt; [at] &lt;HH:MM&gt; [play|force] &lt;query&gt;</b> — Recurring
+<b>/schedule list</b> — Show this chat's schedules
+<b>/schedule del &lt;number&gt;</b> — Remove a schedule
+<b>/schedule clear</b> — Remove all schedules
+
+<b>🕒 Time:</b>
+• <code>20:30</code> — next time the clock shows 20:30
+• <code>30m</code>, <code>1h30m</code> — from now
+
+<b>📅 Days:</b>
+<code>day</code>, <code>weekday</code>, <code>weekend</code> or a list like <code>mon,wed,fri</code>
+
+<b>⚙️ Behavior:</b>
+• <b>play</b> (default) queues the tracks, <b>force</b> plays them right away
+• Use <code>playlist &lt;name&gt;</code> as query to play a saved playlist
+• The assistant joins the voice chat by itself when the time comes, the voice chat must be started
+• Schedules are kept across restarts
+
+<b>💡 Examples:</b>
+<code>/schedule 07:00 force lofi morning mix</code>
+<code>/schedule every weekday at 20:00 play playlist Evening Show</code>
+<code>/schedule del 2</code>`
+}
+
+const (
+	maxSchedulesPerChat = 10
+	// one-off schedules missed by more than this while the bot was down
+	// are dropped instead of played late
+	scheduleGrace = 15 * time.Minute
+)
+
+var (
+	scheduleTimers   = make(map[string]*time.Timer) // schedule ID -> timer
+	scheduleTimersMu sync.Mutex
+)
+
+var scheduleLocation = sync.OnceValue(func() *time.Location {
+	if config.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		gologging.ErrorF("Invalid TIMEZONE %q, using the server's: %v", config.Timezone, err)
+		return time.Local
+	}
+	return loc
+})
+
+var scheduleDays = map[string][]int{
+	"day":      {0, 1, 2, 3, 4, 5, 6},
+	"daily":    {0, 1, 2, 3, 4, 5, 6},
+	"weekday":  {1, 2, 3, 4, 5},
+	"weekdays": {1, 2, 3, 4, 5},
+	"weekend":  {0, 6},
+	"weekends": {0, 6},
+}
+
+var errScheduleSyntax = errors.New("invalid schedule")
+
+func scheduleHandler(m *tg.NewMessage) error {
+	return handleSchedule(m, false)
+}
+
+func cscheduleHandler(m *tg.NewMessage) error {
+	return handleSchedule(m, true)
+}
+
+func handleSchedule(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	args := strings.Fields(m.Args())
+
+	if len(args) == 0 || strings.EqualFold(args[0], "list") {
+		return listSchedules(m)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "del", "delete", "rm", "remove":
+		num := 0
+		if len(args) > 1 {
+			num, _ = strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+		}
+		return deleteSchedule(m, num)
+
+	case "clear":
+		list, err := database.GetSchedules(chatID)
+		if err != nil {
+			m.Reply(F(chatID, "schedule_db_error"))
+			return tg.ErrEndGroup
+		}
+		for _, s := range list {
+			cancelSchedule(s.ID)
+			database.DeleteSchedule(chatID, s.Num)
+		}
+		m.Reply(F(chatID, "schedule_cleared", locales.Arg{
+			"count": len(list),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if cplay {
+		if cplayID, err := database.GetCPlayID(chatID); err != nil || cplayID == 0 {
+			m.Reply(F(chatID, "cplay_id_not_set"))
+			return tg.ErrEndGroup
+		}
+	}
+
+	s, err := parseSchedule(args, time.Now())
+	if err != nil {
+		m.Reply(F(chatID, "schedule_usage", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+	s.ChatID = chatID
+	s.CPlay = cplay
+	s.CreatedBy = m.SenderID()
+
+	if list, err := database.GetSchedules(chatID); err != nil {
+		m.Reply(F(chatID, "schedule_db_error"))
+		return tg.ErrEndGroup
+	} else if len(list) >= maxSchedulesPerChat {
+		m.Reply(F(chatID, "schedule_limit", locales.Arg{
+			"limit": maxSchedulesPerChat,
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.AddSchedule(s); err != nil {
+		m.Reply(F(chatID, "schedule_db_error"))
+		return tg.ErrEndGroup
+	}
+	next := armSchedule(s)
+
+	m.Reply(F(chatID, "schedule_added", locales.Arg{
+		"num":  s.Num,
+		"what": scheduleWhat(chatID, s),
+		"when": scheduleWhen(chatID, s),
+		"next": next.Format("Mon, 02 Jan 15:04 MST"),
+	}))
+	return tg.ErrEndGroup
+}
+
+// parseSchedule reads "<time> [play|force] <query>" or
+// "every <days> [at] <HH:MM> [play|force] <query>".
+func parseSchedule(args []string, now time.Time) (*database.Schedule, error) {
+	s := &database.Schedule{}
+	now = now.In(scheduleLocation())
+
+	if strings.EqualFold(args[0], "every") {
+		if len(args) < 3 {
+			return nil, errScheduleSyntax
+		}
+		days, err := parseScheduleDays(args[1])
+		if err != nil {
+			return nil, err
+		}
+		args = args[2:]
+		if strings.EqualFold(args[0], "at") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return nil, errScheduleSyntax
+		}
+		clock, err := time.Parse("15:04", args[0])
+		if err != nil {
+			return nil, errScheduleSyntax
+		}
+		s.Days = days
+		s.Minute = clock.Hour()*60 + clock.Minute()
+	} else {
+		if strings.EqualFold(args[0], "at") || strings.EqualFold(args[0], "in") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return nil, errScheduleSyntax
+		}
+		if clock, err := time.Parse("15:04", args[0]); err == nil {
+			at := time.Date(
+				now.Year(), now.Month(), now.Day(),
+				clock.Hour(), clock.Minute(), 0, 0, now.Location(),
+			)
+			if !at.After(now) {
+				at = at.AddDate(0, 0, 1)
+			}
+			s.At = at.Unix()
+		} else if d, err := time.ParseDuration(args[0]); err == nil && d >= time.Minute {
+			s.At = now.Add(d).Unix()
+		} else {
+			return nil, errScheduleSyntax
+		}
+	}
+	args = args[1:]
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "play":
+			args = args[1:]
+		case "force", "fplay", "-f":
+			s.Force = true
+			args = args[1:]
+		}
+	}
+	if len(args) > 1 && strings.EqualFold(args[0], "playlist") {
+		s.Playlist = strings.Join(args[1:], " ")
+	} else {
+		s.Query = strings.Join(args, " ")
+	}
+
+	if s.Query == "" && s.Playlist == "" {
+		return nil, errScheduleSyntax
+	}
+	return s, nil
+}
+
+func parseScheduleDays(arg string) ([]int, error) {
+	if days, ok := scheduleDays[strings.ToLower(arg)]; ok {
+		return days, nil
+	}
+
+	var days []int
+	for _, name := range strings.Split(strings.ToLower(arg), ",") {
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			full := strings.ToLower(d.String())
+			if name == full || name == full[:3] {
+				days = append(days, int(d))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errScheduleSyntax
+		}
+	}
+	slices.Sort(days)
+	return slices.Compact(days), nil
+}
+
+// nextRun returns the next time s is due after now, which is in the past
+// for one-off schedules that were missed.
+func nextRun(s *database.Schedule, now time.Time) time.Time {
+	if !s.Recurring() {
+		return time.Unix(s.At, 0).In(scheduleLocation())
+	}
+
+	now = now.In(scheduleLocation())
+	for i := 0; i <= 7; i++ {
+		t := time.Date(
+			now.Year(), now.Month(), now.Day()+i,
+			s.Minute/60, s.Minute%60, 0, 0, now.Location(),
+		)
+		if t.After(now) && slices.Contains(s.Days, int(t.Weekday())) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// armSchedule (re)starts the timer of s and returns when it fires.
+func armSchedule(s *database.Schedule) time.Time {
+	next := nextRun(s, time.Now())
+	if next.IsZero() {
+		return next
+	}
+
+	scheduleTimersMu.Lock()
+	defer scheduleTimersMu.Unlock()
+
+	if t, ok := scheduleTimers[s.ID]; ok {
+		t.Stop()
+	}
+	chatID, num := s.ChatID, s.Num
+	scheduleTimers[s.ID] = time.AfterFunc(time.Until(next), func() {
+		fireSchedule(chatID, num)
+	})
+	return next
+}
+
+func cancelSchedule(id string) {
+	scheduleTimersMu.Lock()
+	defer scheduleTimersMu.Unlock()
+
+	if t, ok := scheduleTimers[id]; ok {
+		t.Stop()
+		delete(scheduleTimers, id)
+	}
+}
+
+// loadSchedules arms every saved schedule after a restart.
+func loadSchedules() {
+	list, err := database.GetAllSchedules()
+	if err != nil {
+		gologging.ErrorF("Failed to load schedules: %v", err)
+		return
+	}
+
+	for _, s := range list {
+		if !s.Recurring() && time.Since(time.Unix(s.At, 0)) > scheduleGrace {
+			gologging.WarnF("Dropping missed schedule %d of %d", s.Num, s.ChatID)
+			database.DeleteSchedule(s.ChatID, s.Num)
+			continue
+		}
+		armSchedule(s)
+	}
+}
+
+func fireSchedule(chatID int64, num int) {
+	// re-read, it may have been removed meanwhile
+	s, err := database.GetSchedule(chatID, num)
+	if err != nil {
+		return
+	}
+
+	if s.Recurring() {
+		armSchedule(s)
+	} else {
+		cancelSchedule(s.ID)
+		database.DeleteSchedule(chatID, num)
+	}
+
+	playSchedule(s)
+}
+
+// playSchedule joins the voice chat if needed and plays s through the
+// same path as /play, reporting in the chat the schedule was made in.
+func playSchedule(s *database.Schedule) {
+	chatID := s.ChatID
+	roomID := chatID
+	if s.CPlay {
+		cplayID, err := database.GetCPlayID(chatID)
+		if err != nil || cplayID == 0 {
+			core.Bot.SendMessage(chatID, F(chatID, "cplay_id_not_set"))
+			return
+		}
+		roomID = cplayID
+	}
+
+	replyMsg, err := core.Bot.SendMessage(chatID, F(chatID, "schedule_firing", locales.Arg{
+		"num":  s.Num,
+		"what": scheduleWhat(chatID, s),
+	}))
+	if err != nil {
+		gologging.ErrorF("Failed to announce schedule %d in %d: %v", s.Num, chatID, err)
+		return
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			handlePanic(rec, replyMsg, true)
+		}
+	}()
+
+	ass, err := core.Assistants.ForChat(roomID)
+	if err != nil {
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return
+	}
+
+	cs, err := core.GetChatState(roomID)
+	if err != nil {
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return
+	}
+
+	// the cached state can be hours old by now
+	if active, err := cs.IsActiveVC(true); err != nil {
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return
+	} else if !active {
+		utils.EOR(replyMsg, F(chatID, "err_no_active_voicechat"))
+		return
+	}
+
+	if present, err := cs.IsAssistantPresent(true); err != nil {
+		utils.EOR(replyMsg, getErrorMessage(chatID, err))
+		return
+	} else if !present {
+		if err := cs.TryJoin(); err != nil {
+			gologging.ErrorF("Failed to join %d for schedule %d: %v", roomID, s.Num, err)
+			utils.EOR(replyMsg, getErrorMessage(chatID, err))
+			return
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	tracks, err := scheduleTracks(s)
+	if err != nil || len(tracks) == 0 {
+		utils.EOR(replyMsg, F(chatID, "no_song_found"))
+		return
+	}
+
+	r, _ := core.GetRoom(roomID, ass, true)
+	r.SetCPlay(s.CPlay)
+	r.Parse()
+
+	playResolvedTracks(replyMsg, core.BUser, replyMsg, r, tracks, s.Force)
+}
+
+func scheduleTracks(s *database.Schedule) ([]*state.Track, error) {
+	if s.Playlist == "" {
+		return platforms.GetTracksByQuery(s.Query, false)
+	}
+
+	// the creator's own playlist first, like /playlist play
+	p, err := database.GetPlaylist(s.CreatedBy, s.Playlist)
+	if err == database.ErrNotFound {
+		p, err = database.GetPlaylist(s.ChatID, s.Playlist)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]*state.Track, len(p.Tracks))
+	for i, t := range p.Tracks {
+		c := *t
+		tracks[i] = &c
+	}
+	return tracks, nil
+}
+
+func listSchedules(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+
+	list, err := database.GetSchedules(chatID)
+	if err != nil {
+		m.Reply(F(chatID, "schedule_db_error"))
+		return tg.ErrEndGroup
+	}
+	if len(list) == 0 {
+		m.Reply(F(chatID, "schedule_list_empty", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	var b strings.Builder
+	b.WriteString(F(chatID, "schedule_list_header", locales.Arg{
+		"zone": scheduleLocation().String(),
+	}))
+	for _, s := range list {
+		b.WriteString("\n\n")
+		b.WriteString(F(chatID, "schedule_list_item", locales.Arg{
+			"num":  s.Num,
+			"what": scheduleWhat(chatID, s),
+			"when": scheduleWhen(chatID, s),
+			"by":   utils.IntToStr(s.CreatedBy),
+		}))
+	}
+
+	m.Reply(b.String())
+	return tg.ErrEndGroup
+}
+
+func deleteSchedule(m *tg.NewMessage, num int) error {
+	chatID := m.ChannelID()
+
+	if num <= 0 {
+		m.Reply(F(chatID, "schedule_usage", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	s, err := database.GetSchedule(chatID, num)
+	if err == database.ErrNotFound {
+		m.Reply(F(chatID, "schedule_not_found", locales.Arg{
+			"num": num,
+		}))
+		return tg.ErrEndGroup
+	} else if err != nil {
+		m.Reply(F(chatID, "schedule_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	cancelSchedule(s.ID)
+	if err := database.DeleteSchedule(chatID, num); err != nil {
+		m.Reply(F(chatID, "schedule_db_error"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "schedule_deleted", locales.Arg{
+		"num": num,
+	}))
+	return tg.ErrEndGroup
+}
+
+func scheduleWhat(chatID int64, s *database.Schedule) string {
+	key := "schedule_what_query"
+	name := s.Query
+	if s.Playlist != "" {
+		key = "schedule_what_playlist"
+		name = s.Playlist
+	}
+	what := F(chatID, key, locales.Arg{
+		"name": html.EscapeString(utils.ShortTitle(name, 40)),
+	})
+	if s.Force {
+		what += " " + F(chatID, "schedule_forced")
+	}
+	return what
+}
+
+func scheduleWhen(chatID int64, s *database.Schedule) string {
+	if !s.Recurring() {
+		return F(chatID, "schedule_when_once", locales.Arg{
+			"time": nextRun(s, time.Now()).Format("Mon, 02 Jan 15:04"),
+		})
+	}
+
+	days := make([]string, len(s.Days))
+	for i, d := range s.Days {
+		days[i] = time.Weekday(d).String()[:3]
+	}
+	return F(chatID, "schedule_when_every", locales.Arg{
+		"days": strings.Join(days, ", "),
+		"time": fmt.Sprintf("%02d:%02d", s.Minute/60, s.Minute%60),
+	})
+}
@@ -0,0 +1,237 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/platforms"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/search"] = `<i>Search and pick the right track before it is queued.</i>
+
+<u>Usage:</u>
+<b>/search &lt;query&gt;</b> — Show the top results of YouTube, SoundCloud and other searchable sources
+
+<b>⚙️ Behavior:</b>
+• Every result shows its duration and channel
+• Tap one to queue it, only the one who searched can pick
+• The buttons expire after a few minutes
+
+<b>💡 Examples:</b>
+<code>/search hello adele</code>
+<code>/search lofi beats</code>`
+
+	helpTexts["/searchmode"] = `<i>Make /play show the search results instead of playing the first one.</i>
+
+<u>Usage:</u>
+<b>/searchmode</b> — Show the current setting
+<b>/searchmode on</b> — /play lets the user pick from the results
+<b>/searchmode off</b> — /play plays the first result (default)
+
+<b>⚙️ Behavior:</b>
+• Only plain text searches are affected, links and replied media play right away
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Example:</b>
+<code>/searchmode on</code>`
+}
+
+const (
+	// searchPerSource is how many results each searchable platform adds
+	// to the picker.
+	searchPerSource = 5
+	// searchPickTTL is how long the buttons of a picker stay usable.
+	searchPickTTL = 5 * time.Minute
+)
+
+var searchPicks = utils.NewCache[string, *picker](searchPickTTL) // chat:message -> results
+
+func searchHandler(m *tg.NewMessage) error {
+	return handleSearch(m, false)
+}
+
+func csearchHandler(m *tg.NewMessage) error {
+	return handleSearch(m, true)
+}
+
+func handleSearch(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	query := strings.TrimSpace(m.Args())
+	if query == "" {
+		m.Reply(F(chatID, "search_usage", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if cplay {
+		if cplayID, err := database.GetCPlayID(chatID); err != nil || cplayID == 0 {
+			m.Reply(F(chatID, "cplay_id_not_set"))
+			return tg.ErrEndGroup
+		}
+	}
+
+	replyMsg, err := m.Reply(F(chatID, "searching_query", locales.Arg{
+		"query": html.EscapeString(query),
+	}))
+	if err != nil {
+		return tg.ErrEndGroup
+	}
+
+	return showSearchResults(m, replyMsg, query, &playOpts{CPlay: cplay})
+}
+
+// useSearchPicker reports whether /play should offer the results of m's
+// query to pick from, which the chat opts into with /searchmode.
+func useSearchPicker(m *tg.NewMessage) bool {
+	if strings.TrimSpace(m.Args()) == "" {
+		return false
+	}
+	if urls, _ := utils.ExtractURLs(m); len(urls) > 0 {
+		return false
+	}
+	on, err := database.GetSearchMode(m.ChannelID())
+	return err == nil && on
+}
+
+// showSearchResults turns replyMsg into a picker of the results for query.
+func showSearchResults(
+	m *tg.NewMessage,
+	replyMsg *tg.NewMessage,
+	query string,
+	opts *playOpts,
+) error {
+	chatID := m.ChannelID()
+
+	tracks, err := platforms.Search(query, searchPerSource, opts.Video)
+	if err != nil {
+		utils.EOR(replyMsg, F(chatID, "no_song_found"))
+		return tg.ErrEndGroup
+	}
+
+	kb := tg.NewKeyboard()
+	for i, t := range tracks {
+		kb.AddRow(tg.Button.Data(searchResultLabel(t), "search:"+strconv.Itoa(i)))
+	}
+	kb.AddRow(tg.Button.Data(F(chatID, "CLOSE_BTN"), "close"))
+
+	replyMsg, err = utils.EOR(replyMsg, F(chatID, "search_results", locales.Arg{
+		"query": html.EscapeString(query),
+		"count": len(tracks),
+	}), &tg.SendOptions{ParseMode: "HTML", ReplyMarkup: kb.Build()})
+	if err != nil || replyMsg == nil {
+		return tg.ErrEndGroup
+	}
+
+	searchPicks.Set(pickKey(chatID, replyMsg.ID), &picker{
+		tracks: tracks,
+		userID: m.SenderID(),
+		cplay:  opts.CPlay,
+		force:  opts.Force,
+	})
+	return tg.ErrEndGroup
+}
+
+// searchResultLabel is the button text of a result, e.g.
+// "Adele - Hello · 6:07 · AdeleVEVO". Results from other sources than
+// YouTube are marked with their platform.
+func searchResultLabel(t *state.Track) string {
+	label := utils.ShortTitle(t.Title, 30)
+	if t.Duration > 0 {
+		label += " · " + formatDuration(t.Duration)
+	}
+	if t.Channel != "" {
+		label += " · " + utils.ShortTitle(t.Channel, 15)
+	}
+	if t.Source != platforms.PlatformYouTube {
+		label += " (" + string(t.Source) + ")"
+	}
+	return label
+}
+
+func searchCB(cb *tg.CallbackQuery) error {
+	chatID := cb.ChannelID()
+
+	// search:<index>
+	idx, err := strconv.Atoi(strings.TrimPrefix(cb.DataString(), "search:"))
+	if err != nil {
+		cb.Answer(F(chatID, "invalid_request"), &tg.CallbackOptions{Alert: true})
+		return tg.ErrEndGroup
+	}
+
+	pick, ok := getPicker(cb, searchPicks, "search")
+	if !ok {
+		return tg.ErrEndGroup
+	}
+	return playPicked(cb, searchPicks, pick, idx, "search", func(t *state.Track) string {
+		return F(chatID, "searching_query", locales.Arg{
+			"query": html.EscapeString(t.Title),
+		})
+	})
+}
+
+func searchModeHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+	args := strings.Fields(strings.ToLower(m.Args()))
+
+	if len(args) == 0 {
+		on, err := database.GetSearchMode(chatID)
+		if err != nil {
+			m.Reply(F(chatID, "searchmode_fetch_fail"))
+			return tg.ErrEndGroup
+		}
+		m.Reply(F(chatID, "searchmode_status", locales.Arg{
+			"state": F(chatID, utils.IfElse(on, "enabled", "disabled")),
+			"cmd":   getCommand(m) + utils.IfElse(on, " off", " on"),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	value, err := utils.ParseBool(args[0])
+	if err != nil {
+		m.Reply(F(chatID, "invalid_bool"))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetSearchMode(chatID, value); err != nil {
+		m.Reply(F(chatID, "searchmode_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "searchmode_updated", locales.Arg{
+		"state": F(chatID, utils.IfElse(value, "enabled", "disabled")),
+		"user":  utils.MentionHTML(m.Sender),
+	}))
+	return tg.ErrEndGroup
+}
@@ -21,15 +21,15 @@
 package modules
 
 import (
-	"context"
+	"errors"
 	"html"
 
 	"github.com/Laky-64/gologging"
 	"github.com/amarnathcjd/gogram/telegram"
 
 	"main/internal/core"
+	state "main/internal/core/models"
 	"main/internal/locales"
-	"main/internal/platforms"
 	"main/internal/utils"
 )
 
@@ -74,14 +74,27 @@ func handleSkip(m *telegram.NewMessage, cplay bool) error {
 		return telegram.ErrEndGroup
 	}
 
-	mention := utils.MentionHTML(m.Sender)
+	if _, err := skipRoom(r, chatID); err == errQueueEnded {
+		m.Reply(F(chatID, "skip_stopped", locales.Arg{
+			"user": utils.MentionHTML(m.Sender),
+		}))
+	}
+	return telegram.ErrEndGroup
+}
+
+// errQueueEnded is returned by skipRoom when there was nothing left to
+// play and the room got stopped instead.
+var errQueueEnded = errors.New("queue ended")
+
+// skipRoom ends the current track of r and starts the next one, keeping
+// chatID informed with the usual download and now playing messages.
+// Download and playback failures are reported there and destroy the room.
+func skipRoom(r *core.RoomState, chatID int64) (*state.Track, error) {
+	r.SetEndReason(state.EndSkipped)
 
 	if len(r.Queue()) == 0 && r.Loop() == 0 {
 		r.Destroy()
-		m.Reply(F(chatID, "skip_stopped", locales.Arg{
-			"user": mention,
-		}))
-		return telegram.ErrEndGroup
+		return nil, errQueueEnded
 	}
 
 	t := r.NextTrack()
@@ -94,7 +107,7 @@ func handleSkip(m *telegram.NewMessage, cplay bool) error {
 		gologging.ErrorF("[skip.go] err: %v", err)
 	}
 
-	path, err := platforms.Download(context.Background(), t, mystic)
+	path, err := downloadNext(r, t, mystic)
 	if err != nil {
 		txt := F(chatID, "stream_download_fail", locales.Arg{
 			"error": err.Error(),
@@ -107,7 +120,7 @@ func handleSkip(m *telegram.NewMessage, cplay bool) error {
 		}
 
 		r.Destroy()
-		return telegram.ErrEndGroup
+		return nil, err
 	}
 
 	if err := r.Play(t, path); err != nil {
@@ -118,7 +131,7 @@ func handleSkip(m *telegram.NewMessage, cplay bool) error {
 			core.Bot.SendMessage(chatID, txt)
 		}
 		r.Destroy()
-		return telegram.ErrEndGroup
+		return nil, err
 	}
 
 	title := utils.ShortTitle(t.Title, 25)
@@ -150,5 +163,5 @@ func handleSkip(m *telegram.NewMessage, cplay bool) error {
 		r.SetMystic(newMystic)
 	}
 
-	return telegram.ErrEndGroup
+	return t, nil
 }
@@ -0,0 +1,260 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+func init() {
+	helpTexts["/sleep"] = `<i>Stop playback after a while.</i>
+
+<u>Usage:</u>
+<b>/sleep</b> — Show the pending sleep timer
+<b>/sleep &lt;duration&gt;</b> — Stop after this long
+<b>/sleep off</b> — Cancel it
+
+<b>⚙️ Behavior:</b>
+• Durations like <code>30m</code> or <code>1h30m</code>, a plain number is minutes (1 minute to 12 hours)
+• The timer shows on the now playing message, tap it to cancel
+• Replaces a pending <code>/stopafter</code>
+
+<b>💡 Examples:</b>
+<code>/sleep 30m</code>
+<code>/sleep 45</code>`
+
+	helpTexts["/stopafter"] = `<i>Stop playback after a number of tracks.</i>
+
+<u>Usage:</u>
+<b>/stopafter</b> — Stop when the current track ends
+<b>/stopafter &lt;count&gt;</b> — Stop after this many more tracks
+<b>/stopafter off</b> — Cancel it
+
+<b>⚙️ Behavior:</b>
+• Only tracks that play to the end are counted
+• The count shows on the now playing message, tap it to cancel
+• Replaces a pending <code>/sleep</code> timer
+
+<b>💡 Examples:</b>
+<code>/stopafter</code>
+<code>/stopafter 3</code>`
+}
+
+const (
+	sleepMin     = time.Minute
+	sleepMax     = 12 * time.Hour
+	stopAfterMax = 100
+)
+
+func sleepHandler(m *tg.NewMessage) error {
+	return handleSleep(m, false)
+}
+
+func csleepHandler(m *tg.NewMessage) error {
+	return handleSleep(m, true)
+}
+
+func stopAfterHandler(m *tg.NewMessage) error {
+	return handleStopAfter(m, false)
+}
+
+func cstopAfterHandler(m *tg.NewMessage) error {
+	return handleStopAfter(m, true)
+}
+
+func handleSleep(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	r, ok := sleepRoom(m, cplay)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+
+	arg := strings.ToLower(strings.TrimSpace(m.Args()))
+	switch arg {
+	case "":
+		m.Reply(sleepStatus(chatID, r, getCommand(m)))
+		return tg.ErrEndGroup
+	case "off", "cancel", "0":
+		cancelSleep(m, r)
+		return tg.ErrEndGroup
+	}
+
+	d, err := time.ParseDuration(arg)
+	if mins, convErr := strconv.Atoi(arg); convErr == nil {
+		d, err = time.Duration(mins)*time.Minute, nil
+	}
+	if err != nil || d < sleepMin || d > sleepMax {
+		m.Reply(F(chatID, "sleep_invalid", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	r.SetSleepTimer(d)
+	refreshNowPlaying(r)
+
+	m.Reply(F(chatID, "sleep_set", locales.Arg{
+		"time": formatDuration(int(d.Seconds())),
+		"user": utils.MentionHTML(m.Sender),
+	}))
+	return tg.ErrEndGroup
+}
+
+func handleStopAfter(m *tg.NewMessage, cplay bool) error {
+	chatID := m.ChannelID()
+	r, ok := sleepRoom(m, cplay)
+	if !ok {
+		return tg.ErrEndGroup
+	}
+
+	arg := strings.ToLower(strings.TrimSpace(m.Args()))
+	if arg == "off" || arg == "cancel" {
+		cancelSleep(m, r)
+		return tg.ErrEndGroup
+	}
+
+	more := 0
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 || n > stopAfterMax {
+			m.Reply(F(chatID, "stopafter_invalid", locales.Arg{
+				"cmd": getCommand(m),
+				"max": stopAfterMax,
+			}))
+			return tg.ErrEndGroup
+		}
+		more = n
+	}
+
+	// the current track plus the ones after it
+	r.SetStopAfter(more + 1)
+	refreshNowPlaying(r)
+
+	key := utils.IfElse(more == 0, "stopafter_set_current", "stopafter_set")
+	m.Reply(F(chatID, key, locales.Arg{
+		"count": more,
+		"user":  utils.MentionHTML(m.Sender),
+	}))
+	return tg.ErrEndGroup
+}
+
+func sleepRoom(m *tg.NewMessage, cplay bool) (*core.RoomState, bool) {
+	r, err := getEffectiveRoom(m, cplay)
+	if err != nil {
+		m.Reply(err.Error())
+		return nil, false
+	}
+	if !r.IsActiveChat() {
+		m.Reply(F(m.ChannelID(), "room_no_active"))
+		return nil, false
+	}
+	return r, true
+}
+
+func cancelSleep(m *tg.NewMessage, r *core.RoomState) {
+	chatID := m.ChannelID()
+	if r.SleepRemaining() <= 0 && r.StopAfter() == 0 {
+		m.Reply(F(chatID, "sleep_none", locales.Arg{
+			"cmd": getCommand(m),
+		}))
+		return
+	}
+
+	r.SetSleepTimer(0)
+	refreshNowPlaying(r)
+	m.Reply(F(chatID, "sleep_cancelled", locales.Arg{
+		"user": utils.MentionHTML(m.Sender),
+	}))
+}
+
+func sleepStatus(chatID int64, r *core.RoomState, cmd string) string {
+	if d := r.SleepRemaining(); d > 0 {
+		return F(chatID, "sleep_status", locales.Arg{
+			"time": formatDuration(int(d.Seconds())),
+		})
+	}
+	if n := r.StopAfter(); n > 0 {
+		return F(chatID, "stopafter_status", locales.Arg{
+			"count": n - 1,
+		})
+	}
+	return F(chatID, "sleep_none", locales.Arg{
+		"cmd": cmd,
+	})
+}
+
+// refreshNowPlaying redraws the buttons of the now playing message so a
+// new or cancelled sleep timer shows up right away, and lets the progress
+// monitor count a new timer down from there.
+func refreshNowPlaying(r *core.RoomState) {
+	scheduleProgress(r.ChatID(), progressMinDelay)
+
+	mystic := r.GetMystic()
+	if mystic == nil {
+		return
+	}
+	if err := editProgress(r, mystic); err != nil &&
+		!tg.MatchError(err, "MESSAGE_NOT_MODIFIED") {
+		gologging.DebugF("Failed to refresh now playing in %d: %v", r.ChatID(), err)
+	}
+}
+
+func handleSleepOffAction(
+	cb *tg.CallbackQuery,
+	r *core.RoomState,
+	chatID int64,
+) error {
+	opt := &tg.CallbackOptions{Alert: true}
+
+	gologging.InfoF("Callback → sleep_off, chatID=%d", chatID)
+
+	r.SetSleepTimer(0)
+	refreshNowPlaying(r)
+	cb.Answer(F(cb.ChannelID(), "cb_sleep_cancelled"), opt)
+	return tg.ErrEndGroup
+}
+
+// onScheduledStop tells the chat that its room stopped because of /sleep
+// or /stopafter.
+func onScheduledStop(r *core.RoomState) {
+	chatID := r.ChatID()
+	if r.IsCPlay() {
+		cid, err := database.GetChatIDFromCPlayID(chatID)
+		if err != nil {
+			return
+		}
+		chatID = cid
+	}
+
+	if _, err := core.Bot.SendMessage(chatID, F(chatID, "sleep_stopped")); err != nil {
+		gologging.ErrorF("Failed to announce scheduled stop in %d: %v", chatID, err)
+	}
+}
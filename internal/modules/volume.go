@@ -0,0 +1,157 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	"main/internal/database"
+	"main/internal/locales"
+	"main/internal/utils"
+)
+
+// volumeStep is how much the volume buttons change the volume.
+const volumeStep = 10
+
+func init() {
+	helpTexts["/volume"] = `<i>Set the stream volume.</i>
+
+<u>Usage:</u>
+<b>/volume</b> — Show the current volume
+<b>/volume [` + strconv.Itoa(core.MinVolume) + `-` + strconv.Itoa(core.MaxVolume) + `]</b> — Set the volume in percent
+
+<b>⚙️ Behavior:</b>
+• 100 is the original loudness; above it the stream is amplified
+• The value is also kept as this chat's default for new streams
+• 🔉 and 🔊 on the player change it in steps of ` + strconv.Itoa(volumeStep) + `
+• Playback restarts at the current position
+
+<b>🔒 Restrictions:</b>
+• Only <b>chat admins</b> or <b>authorized users</b> can use this
+
+<b>💡 Examples:</b>
+<code>/volume 60</code>
+<code>/volume 150</code>`
+}
+
+func volumeHandler(m *tg.NewMessage) error {
+	return handleVolume(m, false)
+}
+
+func cvolumeHandler(m *tg.NewMessage) error {
+	return handleVolume(m, true)
+}
+
+func handleVolume(m *tg.NewMessage, cplay bool) error {
+	r, err := getEffectiveRoom(m, cplay)
+	if err != nil {
+		m.Reply(err.Error())
+		return tg.ErrEndGroup
+	}
+
+	chatID := m.ChannelID()
+	arg := strings.TrimSuffix(strings.TrimSpace(m.Args()), "%")
+
+	if arg == "" {
+		m.Reply(F(chatID, "volume_current", locales.Arg{
+			"volume": r.Volume(),
+			"cmd":    getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	volume, err := strconv.Atoi(arg)
+	if err != nil || volume < core.MinVolume || volume > core.MaxVolume {
+		m.Reply(F(chatID, "volume_invalid", locales.Arg{
+			"min": core.MinVolume,
+			"max": core.MaxVolume,
+			"cmd": getCommand(m),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if err := r.SetVolume(volume); err != nil {
+		m.Reply(F(chatID, "volume_failed", locales.Arg{
+			"error": err.Error(),
+		}))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetVolume(r.ChatID(), volume); err != nil {
+		gologging.ErrorF("Failed to save volume of %d: %v", r.ChatID(), err)
+	}
+
+	m.Reply(F(chatID, "volume_set", locales.Arg{
+		"volume": volume,
+		"user":   utils.MentionHTML(m.Sender),
+	}))
+	return tg.ErrEndGroup
+}
+
+func handleVolumeUpAction(
+	cb *tg.CallbackQuery,
+	r *core.RoomState,
+	chatID int64,
+) error {
+	return stepVolume(cb, r, volumeStep)
+}
+
+func handleVolumeDownAction(
+	cb *tg.CallbackQuery,
+	r *core.RoomState,
+	chatID int64,
+) error {
+	return stepVolume(cb, r, -volumeStep)
+}
+
+func stepVolume(cb *tg.CallbackQuery, r *core.RoomState, delta int) error {
+	opt := &tg.CallbackOptions{Alert: true}
+
+	volume := min(max(r.Volume()+delta, core.MinVolume), core.MaxVolume)
+	if volume == r.Volume() {
+		cb.Answer(F(cb.ChannelID(), "volume_limit", locales.Arg{
+			"volume": volume,
+		}), opt)
+		return tg.ErrEndGroup
+	}
+
+	if err := r.SetVolume(volume); err != nil {
+		gologging.ErrorF("Volume change failed: %v", err)
+		cb.Answer(F(cb.ChannelID(), "volume_failed", locales.Arg{
+			"error": err.Error(),
+		}), opt)
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetVolume(r.ChatID(), volume); err != nil {
+		gologging.ErrorF("Failed to save volume of %d: %v", r.ChatID(), err)
+	}
+
+	cb.Answer(F(cb.ChannelID(), "cb_volume_set", locales.Arg{
+		"volume": volume,
+	}))
+	return tg.ErrEndGroup
+}
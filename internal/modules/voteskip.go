@@ -0,0 +1,185 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package modules
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+	tg "github.com/amarnathcjd/gogram/telegram"
+
+	"main/internal/core"
+	"main/internal/database"
+	"main/internal/locales"
+)
+
+func init() {
+	helpTexts["/voteskip"] = `<i>Vote to skip the current track.</i>
+
+<u>Usage:</u>
+<b>/voteskip</b> — Vote to skip the current track
+<b>/voteskip &lt;percent&gt;</b> — Set the share of listeners needed (admins)
+
+<b>⚙️ Behavior:</b>
+• Only users in the voice chat can vote, once per track
+• The track is skipped once enough listeners have voted
+• Votes reset whenever the track changes
+• The 🗳 button on the player does the same
+
+<b>🔒 Restrictions:</b>
+• Changing the threshold needs <b>chat admin</b> or <b>authorized user</b> rights
+
+<b>💡 Examples:</b>
+<code>/voteskip</code> — Cast your vote
+<code>/voteskip 60</code> — Require 60% of listeners`
+}
+
+func voteSkipHandler(m *tg.NewMessage) error {
+	chatID := m.ChannelID()
+
+	if arg := strings.TrimSpace(m.Args()); arg != "" {
+		return setVoteSkipThreshold(m, arg)
+	}
+
+	r, err := getEffectiveRoom(m, false)
+	if err != nil {
+		m.Reply(err.Error())
+		return tg.ErrEndGroup
+	}
+	if !r.IsActiveChat() {
+		m.Reply(F(chatID, "room_no_active"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(castSkipVote(chatID, r, m.Sender))
+	return tg.ErrEndGroup
+}
+
+func setVoteSkipThreshold(m *tg.NewMessage, arg string) error {
+	chatID := m.ChannelID()
+
+	if !filterAuthUsers(m) {
+		return tg.ErrEndGroup
+	}
+
+	percent, err := strconv.Atoi(strings.TrimSuffix(arg, "%"))
+	if err != nil || percent < 1 || percent > 100 {
+		m.Reply(F(chatID, "voteskip_invalid_percent"))
+		return tg.ErrEndGroup
+	}
+
+	if err := database.SetVoteSkipPercent(chatID, percent); err != nil {
+		m.Reply(F(chatID, "voteskip_update_fail"))
+		return tg.ErrEndGroup
+	}
+
+	m.Reply(F(chatID, "voteskip_threshold_updated", locales.Arg{
+		"percent": percent,
+	}))
+	return tg.ErrEndGroup
+}
+
+func handleVoteSkipAction(
+	cb *tg.CallbackQuery,
+	r *core.RoomState,
+	chatID int64,
+) error {
+	opt := &tg.CallbackOptions{Alert: true}
+
+	if !checkFloodControl(cb, chatID, opt) {
+		return tg.ErrEndGroup
+	}
+
+	cb.Answer(castSkipVote(cb.ChannelID(), r, cb.Sender), opt)
+	return tg.ErrEndGroup
+}
+
+// castSkipVote registers user's vote and skips the track once enough
+// listeners agree. It returns the text to show to the voter.
+func castSkipVote(chatID int64, r *core.RoomState, user *tg.UserObj) string {
+	listeners, err := vcListeners(r.ChatID())
+	if err != nil {
+		gologging.ErrorF("Failed to get participants of %d: %v", r.ChatID(), err)
+		return F(chatID, "voteskip_participants_fail")
+	}
+	if !slices.Contains(listeners, user.ID) {
+		return F(chatID, "voteskip_not_listening")
+	}
+
+	needed := requiredSkipVotes(chatID, len(listeners))
+	votes, added, passed := r.AddSkipVote(user.ID, listeners, needed)
+
+	args := locales.Arg{"votes": votes, "needed": needed}
+	if !added {
+		return F(chatID, "voteskip_already", args)
+	}
+	if !passed {
+		return F(chatID, "voteskip_registered", args)
+	}
+
+	voteSkip(chatID, r, args)
+	return F(chatID, "voteskip_passed", args)
+}
+
+func requiredSkipVotes(chatID int64, listeners int) int {
+	percent, _ := database.GetVoteSkipPercent(chatID)
+	needed := (listeners*percent + 99) / 100
+	return max(needed, 1)
+}
+
+// vcListeners returns the users in the voice chat, without the assistant.
+func vcListeners(chatID int64) ([]int64, error) {
+	ass, err := core.Assistants.ForChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := ass.Ntg.GetParticipants(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(participants))
+	for _, p := range participants {
+		peer, ok := p.Peer.(*tg.PeerUser)
+		if !ok || p.Left || peer.UserID == ass.User.ID {
+			continue
+		}
+		ids = append(ids, peer.UserID)
+	}
+	return ids, nil
+}
+
+func voteSkip(chatID int64, r *core.RoomState, args locales.Arg) {
+	gologging.InfoF("Vote skip passed in %d (%v/%v)", chatID, args["votes"], args["needed"])
+
+	// the voter is answered right away, the next track loads meanwhile
+	go func() {
+		if len(r.Queue()) > 0 || r.Loop() > 0 {
+			core.Bot.SendMessage(chatID, F(chatID, "voteskip_skipped", args))
+		}
+		if _, err := skipRoom(r, chatID); err == errQueueEnded {
+			core.Bot.SendMessage(chatID, F(chatID, "voteskip_stopped", args))
+		}
+	}()
+}
@@ -0,0 +1,250 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// AppleMusicPlatform reads track metadata from the public iTunes lookup
+// API and downloads through YouTube. Playlists have no public API, their
+// songs are taken from the music:song tags of the web page.
+type AppleMusicPlatform struct {
+	name state.PlatformName
+}
+
+type itunesResult struct {
+	WrapperType     string `json:"wrapperType"`
+	Kind            string `json:"kind"`
+	TrackID         int64  `json:"trackId"`
+	TrackName       string `json:"trackName"`
+	ArtistName      string `json:"artistName"`
+	TrackTimeMillis int    `json:"trackTimeMillis"`
+	ArtworkURL100   string `json:"artworkUrl100"`
+	TrackViewURL    string `json:"trackViewUrl"`
+}
+
+var (
+	appleMusicLinkRegex = regexp.MustCompile(
+		`(?i)^(https?://)?(music|itunes|geo\.music)\.apple\.com/`,
+	)
+	appleMusicURLRegex = regexp.MustCompile(
+		`(?i)apple\.com/(?:([a-z]{2})/)?(song|album|playlist)/(?:[^/?]+/)?([\w.-]+)`,
+	)
+	appleMusicSongParam = regexp.MustCompile(`[?&]i=(\d+)`)
+	appleMusicSongMeta  = regexp.MustCompile(
+		`<meta\s+property="music:song"\s+content="([^"]+)"`,
+	)
+	appleMusicCache = utils.NewCache[string, []*state.Track](1 * time.Hour)
+)
+
+const (
+	PlatformAppleMusic state.PlatformName = "AppleMusic"
+
+	itunesLookupURL = "https://itunes.apple.com/lookup"
+	// itunesLookupBatch is how many ids are looked up per request
+	itunesLookupBatch = 150
+)
+
+func init() {
+	Register(94, &AppleMusicPlatform{
+		name: PlatformAppleMusic,
+	})
+}
+
+func (a *AppleMusicPlatform) Name() state.PlatformName {
+	return a.name
+}
+
+func (a *AppleMusicPlatform) IsValid(query string) bool {
+	return appleMusicLinkRegex.MatchString(strings.TrimSpace(query))
+}
+
+func (a *AppleMusicPlatform) IsDownloadSupported(source state.PlatformName) bool {
+	return source == a.name
+}
+
+func (a *AppleMusicPlatform) GetTracks(
+	query string,
+	video bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+
+	cacheKey := "applemusic:" + strings.ToLower(query)
+	if cached, ok := appleMusicCache.Get(cacheKey); ok {
+		return updateVideoFlag(cached, video), nil
+	}
+
+	matches := appleMusicURLRegex.FindStringSubmatch(query)
+	if len(matches) < 4 {
+		return nil, errors.New("invalid Apple Music URL format. Supported: songs, albums, playlists")
+	}
+	country := utils.IfElse(matches[1] != "", strings.ToLower(matches[1]), "us")
+	kind, id := strings.ToLower(matches[2]), matches[3]
+
+	var (
+		tracks []*state.Track
+		err    error
+	)
+	switch {
+	case kind == "song":
+		tracks, err = a.lookup(country, []string{id}, false)
+	case kind == "album" && appleMusicSongParam.MatchString(query):
+		// a song opened from its album page
+		songID := appleMusicSongParam.FindStringSubmatch(query)[1]
+		tracks, err = a.lookup(country, []string{songID}, false)
+	case kind == "album":
+		tracks, err = a.lookup(country, []string{id}, true)
+	default:
+		tracks, err = a.getPlaylist(country, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tracks) == 0 {
+		return nil, errors.New("no tracks found")
+	}
+
+	appleMusicCache.Set(cacheKey, tracks)
+	return updateVideoFlag(tracks, video), nil
+}
+
+func (a *AppleMusicPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	mystic *telegram.NewMessage,
+) (string, error) {
+	return downloadViaYouTube(ctx, track, mystic, "AppleMusic")
+}
+
+// lookup resolves song ids, or with album set the songs of an album id,
+// through the iTunes lookup API.
+func (a *AppleMusicPlatform) lookup(
+	country string,
+	ids []string,
+	album bool,
+) ([]*state.Track, error) {
+	client := newMetadataClient()
+	defer client.Close()
+
+	var tracks []*state.Track
+	for start := 0; start < len(ids); start += itunesLookupBatch {
+		batch := ids[start:min(start+itunesLookupBatch, len(ids))]
+
+		req := client.R().
+			SetQueryParam("id", strings.Join(batch, ",")).
+			SetQueryParam("country", country)
+		if album {
+			req.SetQueryParam("entity", "song")
+		}
+
+		resp, err := req.Get(itunesLookupURL)
+		if err != nil {
+			return nil, fmt.Errorf("Apple Music lookup failed: %w", err)
+		}
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("Apple Music lookup returned status %d", resp.StatusCode())
+		}
+
+		var body struct {
+			Results []itunesResult `json:"results"`
+		}
+		if err := json.Unmarshal(resp.Bytes(), &body); err != nil {
+			return nil, fmt.Errorf("failed to parse Apple Music lookup: %w", err)
+		}
+
+		for i := range body.Results {
+			r := &body.Results[i]
+			if r.WrapperType != "track" || r.Kind != "song" {
+				continue
+			}
+			tracks = append(tracks, a.convertTrack(r))
+		}
+	}
+	return tracks, nil
+}
+
+// getPlaylist collects the song links of a public playlist page and looks
+// them up.
+func (a *AppleMusicPlatform) getPlaylist(country, link string) ([]*state.Track, error) {
+	if !strings.HasPrefix(link, "http") {
+		link = "https://" + link
+	}
+
+	client := newMetadataClient()
+	defer client.Close()
+
+	resp, err := client.R().Get(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Apple Music playlist: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("Apple Music playlist returned status %d", resp.StatusCode())
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range appleMusicSongMeta.FindAllStringSubmatch(resp.String(), -1) {
+		var id string
+		if p := appleMusicSongParam.FindStringSubmatch(m[1]); len(p) > 1 {
+			id = p[1]
+		} else if u := appleMusicURLRegex.FindStringSubmatch(m[1]); len(u) > 3 {
+			id = u[3]
+		}
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("playlist is empty or not public")
+	}
+	return a.lookup(country, ids, false)
+}
+
+func (a *AppleMusicPlatform) convertTrack(r *itunesResult) *state.Track {
+	// artworkUrl100 has the size in its name, ask for a bigger one
+	artwork := strings.Replace(r.ArtworkURL100, "100x100bb", "600x600bb", 1)
+
+	return &state.Track{
+		ID:       "applemusic_" + strconv.FormatInt(r.TrackID, 10),
+		Title:    artistTitle(r.ArtistName, r.TrackName),
+		Duration: r.TrackTimeMillis / 1000,
+		Artwork:  artwork,
+		URL:      r.TrackViewURL,
+		Source:   PlatformAppleMusic,
+	}
+}
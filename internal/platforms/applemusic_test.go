@@ -0,0 +1,85 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import "testing"
+
+func TestAppleMusicLinks(t *testing.T) {
+	tests := []struct {
+		url     string
+		valid   bool
+		country string
+		kind    string
+		id      string
+	}{
+		{"https://music.apple.com/us/song/blinding-lights/1499378615", true, "us", "song", "1499378615"},
+		{"https://music.apple.com/us/album/after-hours/1499378108", true, "us", "album", "1499378108"},
+		{"https://music.apple.com/gb/playlist/todays-hits/pl.f4d106fed2bd41149aaacabb233eb5eb", true, "gb", "playlist", "pl.f4d106fed2bd41149aaacabb233eb5eb"},
+		{"https://music.apple.com/album/1499378108", true, "", "album", "1499378108"},
+		{"music.apple.com/IN/album/after-hours/1499378108?ls", true, "IN", "album", "1499378108"},
+		{"http://itunes.apple.com/us/album/after-hours/1499378108", true, "us", "album", "1499378108"},
+		{"https://geo.music.apple.com/us/song/blinding-lights/1499378615", true, "us", "song", "1499378615"},
+		{"https://music.apple.com/us/artist/the-weeknd/479756766", true, "", "", ""},
+		{"https://www.apple.com/apple-music/", false, "", "", ""},
+		{"https://open.spotify.com/track/0VjIjW4GlUZAMYd2vXMi3b", false, "", "", ""},
+	}
+
+	a := &AppleMusicPlatform{}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := a.IsValid(tt.url); got != tt.valid {
+				t.Fatalf("IsValid = %v, want %v", got, tt.valid)
+			}
+			m := appleMusicURLRegex.FindStringSubmatch(tt.url)
+			if tt.kind == "" {
+				if m != nil {
+					t.Fatalf("matched %q, want no match", m)
+				}
+				return
+			}
+			if m == nil || m[1] != tt.country || m[2] != tt.kind || m[3] != tt.id {
+				t.Fatalf("match = %q, want country %q, %s %s", m, tt.country, tt.kind, tt.id)
+			}
+		})
+	}
+}
+
+func TestAppleMusicSongParam(t *testing.T) {
+	tests := []struct {
+		url  string
+		song string
+	}{
+		{"https://music.apple.com/us/album/after-hours/1499378108?i=1499378615", "1499378615"},
+		{"https://music.apple.com/us/album/after-hours/1499378108?l=en&i=1499378615", "1499378615"},
+		{"https://music.apple.com/us/album/after-hours/1499378108?ls=1", ""},
+	}
+
+	for _, tt := range tests {
+		m := appleMusicSongParam.FindStringSubmatch(tt.url)
+		got := ""
+		if m != nil {
+			got = m[1]
+		}
+		if got != tt.song {
+			t.Errorf("song of %s = %q, want %q", tt.url, got, tt.song)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+type BandcampPlatform struct {
+	name state.PlatformName
+}
+
+var (
+	bandcampLinkRegex = regexp.MustCompile(
+		`(?i)^(https?://)?([a-z0-9-]+)\.bandcamp\.com/(album|track)/[^/?#]+`,
+	)
+	bandcampCache = utils.NewCache[string, []*state.Track](1 * time.Hour)
+)
+
+const PlatformBandcamp state.PlatformName = "Bandcamp"
+
+func init() {
+	Register(84, &BandcampPlatform{
+		name: PlatformBandcamp,
+	})
+}
+
+func (b *BandcampPlatform) Name() state.PlatformName {
+	return b.name
+}
+
+func (b *BandcampPlatform) IsValid(query string) bool {
+	return bandcampLinkRegex.MatchString(strings.TrimSpace(query))
+}
+
+func (b *BandcampPlatform) GetTracks(
+	query string,
+	_ bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+
+	cacheKey := "bandcamp:" + strings.ToLower(query)
+	if cached, ok := bandcampCache.Get(cacheKey); ok {
+		gologging.Debug("Bandcamp: Using cached tracks")
+		return cached, nil
+	}
+
+	gologging.InfoF("Bandcamp: Fetching metadata for %s", query)
+
+	info, err := extractYtDlpInfo("Bandcamp", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []*state.Track
+	if len(info.Entries) > 0 {
+		for i := range info.Entries {
+			if t := b.infoToTrack(&info.Entries[i]); t != nil {
+				tracks = append(tracks, t)
+			}
+		}
+	} else if t := b.infoToTrack(info); t != nil {
+		tracks = append(tracks, t)
+	}
+
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no playable tracks found")
+	}
+
+	bandcampCache.Set(cacheKey, tracks)
+	gologging.InfoF("Bandcamp: Extracted %d track(s)", len(tracks))
+	return tracks, nil
+}
+
+func (b *BandcampPlatform) IsDownloadSupported(
+	source state.PlatformName,
+) bool {
+	return source == PlatformBandcamp
+}
+
+func (b *BandcampPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	_ *telegram.NewMessage,
+) (string, error) {
+	return downloadYtDlp(ctx, "Bandcamp", track)
+}
+
+func (b *BandcampPlatform) infoToTrack(info *ytdlpInfo) *state.Track {
+	url := info.pageURL()
+	if url == "" || info.ID == "" {
+		return nil
+	}
+
+	return &state.Track{
+		ID:       "bandcamp_" + info.ID,
+		Title:    info.Title,
+		Duration: int(info.Duration),
+		Artwork:  info.Thumbnail,
+		URL:      url,
+		Source:   PlatformBandcamp,
+		IsLive:   false,
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Laky-64/gologging"
 	"github.com/amarnathcjd/gogram/telegram"
@@ -103,64 +104,18 @@ func GetTracks(m *telegram.NewMessage, video bool) ([]*state.Track, error) {
 	urls, _ := utils.ExtractURLs(m)
 	query := m.Args()
 
-	var allTracks []*state.Track
 	var errorsL []string
 
 	// Process URLs first
 	if len(urls) > 0 {
-
-		for _, url := range urls {
-			gologging.Info("Processing URL: " + url)
-
-			platform := FindPlatform(url)
-			if platform == nil {
-				errMsg := "No platform found for URL: " + url
-				gologging.Error(errMsg)
-				errorsL = append(errorsL, errMsg)
-				continue
-			}
-
-			gologging.Debug("Found platform: " + string(platform.Name()))
-
-			tracks, err := platform.GetTracks(url, video)
-			if err != nil {
-				errMsg := string(platform.Name()) + ": " + err.Error()
-				gologging.Error(errMsg)
-				errorsL = append(errorsL, errMsg)
-				continue
-			}
-
-			gologging.Info("Tracks found: " + strconv.Itoa(len(tracks)))
-			allTracks = append(allTracks, tracks...)
-		}
-
-		// If we have tracks from URLs, return them
-		if len(allTracks) > 0 {
-			gologging.Info("Returning tracks from URLs")
-			return allTracks, nil
-		}
-
-		if len(errorsL) == 0 {
-			return nil, errors.New("No supported platform for given URL(s)")
-		}
-		return nil, formatErrors(errorsL)
-
+		return getTracksFromURLs(urls, video)
 	}
 
 	// If no URLs but have query, search YouTube
 	if query != "" {
-		gologging.Info("No URLs found, searching YouTube with query: " + query)
-
-		yt := &YouTubePlatform{}
-		tracks, err := yt.GetTracks(query, video)
-		if err != nil {
-			gologging.Error("YouTube search failed: " + err.Error())
-			return nil, err
-		}
-
-		if len(tracks) > 0 {
-			gologging.Info("YouTube track found, returning first result")
-			return []*state.Track{tracks[0]}, nil
+		tracks, err := searchTrack(query, video)
+		if err != nil || len(tracks) > 0 {
+			return tracks, err
 		}
 	}
 
@@ -250,6 +205,146 @@ func GetTracks(m *telegram.NewMessage, video bool) ([]*state.Track, error) {
 	return nil, errors.New("no tracks found")
 }
 
+// GetTracksByQuery resolves a plain text query or space separated URLs,
+// for callers that don't have a message to pass to GetTracks.
+func GetTracksByQuery(query string, video bool) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("no tracks found")
+	}
+
+	var urls []string
+	for _, f := range strings.Fields(query) {
+		if strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://") {
+			urls = append(urls, f)
+		}
+	}
+	if len(urls) > 0 {
+		return getTracksFromURLs(urls, video)
+	}
+
+	tracks, err := searchTrack(query, video)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("no tracks found")
+	}
+	return tracks, nil
+}
+
+// Search asks every platform that implements state.Searcher for up to
+// limit results of query at once. Results come grouped by platform in
+// priority order; a failing platform only drops its own results.
+func Search(query string, limit int, video bool) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+
+	var searchers []state.Platform
+	for _, p := range GetOrderedPlatforms() {
+		if _, ok := p.(state.Searcher); ok {
+			searchers = append(searchers, p)
+		}
+	}
+
+	results := make([][]*state.Track, len(searchers))
+	errs := make([]error, len(searchers))
+
+	var wg sync.WaitGroup
+	for i, p := range searchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = p.(state.Searcher).Search(query, limit, video)
+		}()
+	}
+	wg.Wait()
+
+	var tracks []*state.Track
+	var errorsL []string
+	for i, p := range searchers {
+		if errs[i] != nil {
+			errMsg := string(p.Name()) + ": " + errs[i].Error()
+			gologging.Error("Search failed on " + errMsg)
+			errorsL = append(errorsL, errMsg)
+			continue
+		}
+		if len(results[i]) > limit {
+			results[i] = results[i][:limit]
+		}
+		tracks = append(tracks, results[i]...)
+	}
+
+	if len(tracks) == 0 {
+		if len(errorsL) > 0 {
+			return nil, formatErrors(errorsL)
+		}
+		return nil, errors.New("no tracks found")
+	}
+	return tracks, nil
+}
+
+func getTracksFromURLs(urls []string, video bool) ([]*state.Track, error) {
+	var allTracks []*state.Track
+	var errorsL []string
+
+	for _, url := range urls {
+		gologging.Info("Processing URL: " + url)
+
+		platform := FindPlatform(url)
+		if platform == nil {
+			errMsg := "No platform found for URL: " + url
+			gologging.Error(errMsg)
+			errorsL = append(errorsL, errMsg)
+			continue
+		}
+
+		gologging.Debug("Found platform: " + string(platform.Name()))
+
+		tracks, err := platform.GetTracks(url, video)
+		if err != nil {
+			errMsg := string(platform.Name()) + ": " + err.Error()
+			gologging.Error(errMsg)
+			errorsL = append(errorsL, errMsg)
+			continue
+		}
+
+		gologging.Info("Tracks found: " + strconv.Itoa(len(tracks)))
+		allTracks = append(allTracks, tracks...)
+	}
+
+	// If we have tracks from URLs, return them
+	if len(allTracks) > 0 {
+		gologging.Info("Returning tracks from URLs")
+		return allTracks, nil
+	}
+
+	if len(errorsL) == 0 {
+		return nil, errors.New("No supported platform for given URL(s)")
+	}
+	return nil, formatErrors(errorsL)
+}
+
+// searchTrack searches YouTube and returns only the best match.
+func searchTrack(query string, video bool) ([]*state.Track, error) {
+	gologging.Info("No URLs found, searching YouTube with query: " + query)
+
+	yt := &YouTubePlatform{}
+	tracks, err := yt.GetTracks(query, video)
+	if err != nil {
+		gologging.Error("YouTube search failed: " + err.Error())
+		return nil, err
+	}
+
+	if len(tracks) > 0 {
+		gologging.Info("YouTube track found, returning first result")
+		return []*state.Track{tracks[0]}, nil
+	}
+	return nil, nil
+}
+
 // Download attempts to download a track using available downloaders
 func Download(
 	ctx context.Context,
@@ -263,8 +358,13 @@ func Download(
 			continue
 		}
 
+		start := time.Now()
 		path, err := p.Download(ctx, track, mystic)
+		if !errors.Is(err, context.Canceled) {
+			recordDownload(p.Name(), time.Since(start), err)
+		}
 		if err == nil {
+			analyzeLoudness(ctx, track, path)
 			// Special case: DirectStream returns the URL itself, not a file path
 			// The streaming system will handle it
 			return path, nil
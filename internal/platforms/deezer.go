@@ -0,0 +1,260 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+type DeezerPlatform struct {
+	name state.PlatformName
+}
+
+type deezerTrack struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Duration int    `json:"duration"`
+	Link     string `json:"link"`
+	Artist   struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Album struct {
+		CoverXL string `json:"cover_xl"`
+	} `json:"album"`
+}
+
+type deezerTrackList struct {
+	Data []deezerTrack `json:"data"`
+	Next string        `json:"next"`
+}
+
+type deezerCollection struct {
+	CoverXL   string          `json:"cover_xl"`
+	PictureXL string          `json:"picture_xl"`
+	Tracks    deezerTrackList `json:"tracks"`
+}
+
+type deezerError struct {
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+var (
+	deezerLinkRegex = regexp.MustCompile(
+		`(?i)^(https?://)?(www\.)?(deezer\.com|deezer\.page\.link|link\.deezer\.com)/`,
+	)
+	deezerURLRegex = regexp.MustCompile(
+		`(?i)deezer\.com/(?:[a-z]{2}(?:-[a-z]{2})?/)?(track|album|playlist)/(\d+)`,
+	)
+	deezerCache = utils.NewCache[string, []*state.Track](1 * time.Hour)
+)
+
+const (
+	PlatformDeezer state.PlatformName = "Deezer"
+
+	deezerAPI = "https://api.deezer.com"
+	// deezerMaxTracks caps albums and playlists, the queue is limited anyway
+	deezerMaxTracks = 500
+)
+
+func init() {
+	Register(93, &DeezerPlatform{
+		name: PlatformDeezer,
+	})
+}
+
+func (d *DeezerPlatform) Name() state.PlatformName {
+	return d.name
+}
+
+func (d *DeezerPlatform) IsValid(query string) bool {
+	return deezerLinkRegex.MatchString(strings.TrimSpace(query))
+}
+
+func (d *DeezerPlatform) IsDownloadSupported(source state.PlatformName) bool {
+	return source == d.name
+}
+
+func (d *DeezerPlatform) GetTracks(
+	query string,
+	video bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+
+	cacheKey := "deezer:" + strings.ToLower(query)
+	if cached, ok := deezerCache.Get(cacheKey); ok {
+		return updateVideoFlag(cached, video), nil
+	}
+
+	link, err := d.resolveShortLink(query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := deezerURLRegex.FindStringSubmatch(link)
+	if len(matches) < 3 {
+		return nil, errors.New("invalid Deezer URL format. Supported: tracks, albums, playlists")
+	}
+	kind, id := strings.ToLower(matches[1]), matches[2]
+
+	var tracks []*state.Track
+	if kind == "track" {
+		var t deezerTrack
+		if err := d.get("/track/"+id, &t); err != nil {
+			return nil, err
+		}
+		tracks = []*state.Track{d.convertTrack(&t, "")}
+	} else {
+		tracks, err = d.getCollection(kind, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tracks) == 0 {
+		return nil, errors.New("no tracks found")
+	}
+
+	deezerCache.Set(cacheKey, tracks)
+	return updateVideoFlag(tracks, video), nil
+}
+
+func (d *DeezerPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	mystic *telegram.NewMessage,
+) (string, error) {
+	return downloadViaYouTube(ctx, track, mystic, "Deezer")
+}
+
+// resolveShortLink follows deezer.page.link and link.deezer.com share
+// links to the deezer.com URL they point to.
+func (d *DeezerPlatform) resolveShortLink(link string) (string, error) {
+	if deezerURLRegex.MatchString(link) {
+		return link, nil
+	}
+	if !strings.HasPrefix(link, "http") {
+		link = "https://" + link
+	}
+
+	client := newMetadataClient()
+	defer client.Close()
+
+	resp, err := client.R().Get(link)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Deezer link: %w", err)
+	}
+	if resp.RawResponse == nil || resp.RawResponse.Request == nil {
+		return "", errors.New("failed to resolve Deezer link")
+	}
+
+	final := resp.RawResponse.Request.URL.String()
+	gologging.DebugF("Deezer: %s resolved to %s", link, final)
+	return final, nil
+}
+
+// getCollection returns the tracks of an album or playlist, following the
+// API's pagination.
+func (d *DeezerPlatform) getCollection(kind, id string) ([]*state.Track, error) {
+	var c deezerCollection
+	if err := d.get("/"+kind+"/"+id, &c); err != nil {
+		return nil, err
+	}
+
+	cover := utils.IfElse(c.CoverXL != "", c.CoverXL, c.PictureXL)
+
+	var tracks []*state.Track
+	page := c.Tracks
+	for {
+		for i := range page.Data {
+			tracks = append(tracks, d.convertTrack(&page.Data[i], cover))
+		}
+		if page.Next == "" || len(tracks) >= deezerMaxTracks {
+			break
+		}
+
+		path := strings.TrimPrefix(page.Next, deezerAPI)
+		page = deezerTrackList{}
+		if err := d.get(path, &page); err != nil {
+			gologging.WarnF("Deezer: stopping %s %s at %d tracks: %v", kind, id, len(tracks), err)
+			break
+		}
+	}
+
+	if len(tracks) > deezerMaxTracks {
+		tracks = tracks[:deezerMaxTracks]
+	}
+	return tracks, nil
+}
+
+func (d *DeezerPlatform) get(path string, out any) error {
+	client := newMetadataClient()
+	defer client.Close()
+
+	resp, err := client.R().Get(deezerAPI + path)
+	if err != nil {
+		return fmt.Errorf("Deezer request failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("Deezer returned status %d", resp.StatusCode())
+	}
+
+	// errors come back as 200 with an "error" object
+	var apiErr deezerError
+	if err := json.Unmarshal(resp.Bytes(), &apiErr); err == nil && apiErr.Error != nil {
+		return errors.New("Deezer: " + apiErr.Error.Message)
+	}
+	return json.Unmarshal(resp.Bytes(), out)
+}
+
+func (d *DeezerPlatform) convertTrack(t *deezerTrack, cover string) *state.Track {
+	if t.Album.CoverXL != "" {
+		cover = t.Album.CoverXL
+	}
+
+	link := t.Link
+	if link == "" {
+		link = "https://www.deezer.com/track/" + strconv.FormatInt(t.ID, 10)
+	}
+
+	return &state.Track{
+		ID:       "deezer_" + strconv.FormatInt(t.ID, 10),
+		Title:    artistTitle(t.Artist.Name, t.Title),
+		Duration: t.Duration,
+		Artwork:  cover,
+		URL:      link,
+		Source:   PlatformDeezer,
+	}
+}
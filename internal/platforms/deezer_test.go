@@ -0,0 +1,61 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import "testing"
+
+func TestDeezerLinks(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+		kind  string
+		id    string
+	}{
+		{"https://www.deezer.com/track/3135556", true, "track", "3135556"},
+		{"https://www.deezer.com/en/album/302127", true, "album", "302127"},
+		{"https://www.deezer.com/pt-br/playlist/908622995?utm_source=share", true, "playlist", "908622995"},
+		{"deezer.com/FR/Track/3135556", true, "Track", "3135556"},
+		{"https://deezer.page.link/ezJPxS3fGSKWpmHr8", true, "", ""},
+		{"https://link.deezer.com/s/30ABCdefGH", true, "", ""},
+		{"https://www.deezer.com/us/artist/27", true, "", ""},
+		{"https://www.deezer.com.example.org/track/3135556", false, "", ""},
+		{"https://music.youtube.com/watch?v=3135556", false, "", ""},
+	}
+
+	d := &DeezerPlatform{}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := d.IsValid(tt.url); got != tt.valid {
+				t.Fatalf("IsValid = %v, want %v", got, tt.valid)
+			}
+			m := deezerURLRegex.FindStringSubmatch(tt.url)
+			if tt.kind == "" {
+				if m != nil {
+					t.Fatalf("matched %q, want no match", m)
+				}
+				return
+			}
+			if m == nil || m[1] != tt.kind || m[2] != tt.id {
+				t.Fatalf("match = %q, want %s %s", m, tt.kind, tt.id)
+			}
+		})
+	}
+}
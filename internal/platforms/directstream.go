@@ -71,6 +71,8 @@ type streamInfo struct {
 	IsAudio     bool
 	IsVideo     bool
 	Duration    int
+	IsLive      bool   // Icecast/SHOUTcast station
+	StationName string // icy-name header
 }
 
 func init() {
@@ -212,6 +214,9 @@ func (d *DirectStreamPlatform) validateStream(
 
 	contentType := resp.Header().Get("Content-Type")
 	contentLength := resp.Header().Get("Content-Length")
+	icyName := strings.TrimSpace(resp.Header().Get("icy-name"))
+	isICY := icyName != "" || resp.Header().Get("icy-br") != "" ||
+		resp.Header().Get("icy-metaint") != ""
 
 	// Check if it's audio/video
 	isAudio := false
@@ -249,6 +254,8 @@ func (d *DirectStreamPlatform) validateStream(
 		IsAudio:     isAudio,
 		IsVideo:     isVideo,
 		Duration:    0, // Will be detected during playback
+		IsLive:      isICY,
+		StationName: icyName,
 	}
 
 	return info, nil
@@ -304,6 +311,16 @@ func (d *DirectStreamPlatform) enrichMetadata(
 	filename := d.extractTitle(track.URL)
 	track.Title = strings.TrimSpace(filename)
 
+	// radio stations never end, play them from the network as they are
+	if info.IsLive {
+		track.IsLive = true
+		track.Duration = 0
+		if info.StationName != "" {
+			track.Title = info.StationName
+		}
+		return
+	}
+
 	if info.IsVideo {
 		track.Video = true
 	}
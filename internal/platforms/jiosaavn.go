@@ -0,0 +1,217 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// JioSaavnPlatform reads metadata from JioSaavn's web API and downloads
+// through YouTube.
+type JioSaavnPlatform struct {
+	name state.PlatformName
+}
+
+type jiosaavnSong struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Image    string `json:"image"`
+	PermaURL string `json:"perma_url"`
+	MoreInfo struct {
+		Duration  string `json:"duration"`
+		ArtistMap struct {
+			PrimaryArtists []struct {
+				Name string `json:"name"`
+			} `json:"primary_artists"`
+		} `json:"artistMap"`
+	} `json:"more_info"`
+}
+
+var (
+	jiosaavnLinkRegex = regexp.MustCompile(
+		`(?i)^(https?://)?(www\.)?(jiosaavn\.com|saavn\.com)/`,
+	)
+	jiosaavnURLRegex = regexp.MustCompile(
+		`(?i)saavn\.com/(?:s/)?(song|album|featured|playlist)/(?:[^/?]+/)*([\w-]+)/?(?:[?#].*)?$`,
+	)
+	jiosaavnCache = utils.NewCache[string, []*state.Track](2 * time.Hour)
+)
+
+const (
+	PlatformJioSaavn state.PlatformName = "JioSaavn"
+
+	jiosaavnAPI = "https://www.jiosaavn.com/api.php"
+	// jiosaavnMaxTracks is the page size asked for on playlists
+	jiosaavnMaxTracks = 500
+)
+
+func init() {
+	Register(88, &JioSaavnPlatform{
+		name: PlatformJioSaavn,
+	})
+}
+
+func (j *JioSaavnPlatform) Name() state.PlatformName {
+	return j.name
+}
+
+func (j *JioSaavnPlatform) IsValid(query string) bool {
+	return jiosaavnLinkRegex.MatchString(strings.TrimSpace(query))
+}
+
+func (j *JioSaavnPlatform) IsDownloadSupported(source state.PlatformName) bool {
+	return source == j.name
+}
+
+func (j *JioSaavnPlatform) GetTracks(
+	query string,
+	video bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+
+	cacheKey := "jiosaavn:" + strings.ToLower(query)
+	if cached, ok := jiosaavnCache.Get(cacheKey); ok {
+		return updateVideoFlag(cached, video), nil
+	}
+
+	matches := jiosaavnURLRegex.FindStringSubmatch(query)
+	if len(matches) < 3 {
+		return nil, errors.New("invalid JioSaavn URL format. Supported: songs, albums, playlists")
+	}
+	kind, token := strings.ToLower(matches[1]), matches[2]
+
+	var (
+		songs []jiosaavnSong
+		err   error
+	)
+	switch kind {
+	case "song":
+		var body struct {
+			Songs []jiosaavnSong `json:"songs"`
+		}
+		err = j.get("song", token, &body)
+		songs = body.Songs
+	case "album":
+		var body struct {
+			List []jiosaavnSong `json:"list"`
+		}
+		err = j.get("album", token, &body)
+		songs = body.List
+	default:
+		var body struct {
+			List []jiosaavnSong `json:"list"`
+		}
+		err = j.get("playlist", token, &body)
+		songs = body.List
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]*state.Track, 0, len(songs))
+	for i := range songs {
+		tracks = append(tracks, j.convertSong(&songs[i]))
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("no tracks found")
+	}
+
+	jiosaavnCache.Set(cacheKey, tracks)
+	return updateVideoFlag(tracks, video), nil
+}
+
+func (j *JioSaavnPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	mystic *telegram.NewMessage,
+) (string, error) {
+	return downloadViaYouTube(ctx, track, mystic, "JioSaavn")
+}
+
+// get calls the webapi.get endpoint for the token of a song, album or
+// playlist link.
+func (j *JioSaavnPlatform) get(kind, token string, out any) error {
+	client := newMetadataClient()
+	defer client.Close()
+
+	resp, err := client.R().
+		SetQueryParams(map[string]string{
+			"__call":          "webapi.get",
+			"token":           token,
+			"type":            kind,
+			"n":               strconv.Itoa(jiosaavnMaxTracks),
+			"p":               "1",
+			"includeMetaTags": "0",
+			"ctx":             "web6dot0",
+			"api_version":     "4",
+			"_format":         "json",
+			"_marker":         "0",
+		}).
+		Get(jiosaavnAPI)
+	if err != nil {
+		return fmt.Errorf("JioSaavn request failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("JioSaavn returned status %d", resp.StatusCode())
+	}
+
+	if err := json.Unmarshal(resp.Bytes(), out); err != nil {
+		// unknown tokens come back as an empty array instead of an object
+		return errors.New("JioSaavn: nothing found for this link")
+	}
+	return nil
+}
+
+func (j *JioSaavnPlatform) convertSong(s *jiosaavnSong) *state.Track {
+	var artists []string
+	for _, a := range s.MoreInfo.ArtistMap.PrimaryArtists {
+		artists = append(artists, a.Name)
+	}
+	artist := strings.Join(artists, ", ")
+	if artist == "" {
+		artist = s.Subtitle
+	}
+
+	duration, _ := strconv.Atoi(s.MoreInfo.Duration)
+
+	return &state.Track{
+		ID:       "jiosaavn_" + s.ID,
+		Title:    artistTitle(html.UnescapeString(artist), html.UnescapeString(s.Title)),
+		Duration: duration,
+		Artwork:  strings.Replace(s.Image, "150x150", "500x500", 1),
+		URL:      s.PermaURL,
+		Source:   PlatformJioSaavn,
+	}
+}
@@ -0,0 +1,61 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import "testing"
+
+func TestJioSaavnLinks(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+		kind  string
+		token string
+	}{
+		{"https://www.jiosaavn.com/song/tum-hi-ho/EToxUyFpcwQ", true, "song", "EToxUyFpcwQ"},
+		{"https://www.jiosaavn.com/song/tum-hi-ho/EToxUyFpcwQ?referrer=svl", true, "song", "EToxUyFpcwQ"},
+		{"https://www.jiosaavn.com/album/aashiqui-2/8EzUQ4BdjKg_/", true, "album", "8EzUQ4BdjKg_"},
+		{"https://www.jiosaavn.com/featured/romantic-top-40/m9Qkal5S733ufxkxMEIbIw__", true, "featured", "m9Qkal5S733ufxkxMEIbIw__"},
+		{"https://www.jiosaavn.com/s/playlist/c4b1e1d5a/my-mix/LdbVc1Z5i9E_", true, "playlist", "LdbVc1Z5i9E_"},
+		{"saavn.com/song/tum-hi-ho/EToxUyFpcwQ#top", true, "song", "EToxUyFpcwQ"},
+		{"https://www.jiosaavn.com/artist/arijit-singh-songs/LlRWpHzy3Hk_", true, "", ""},
+		{"https://www.jiosaavn.com.example.org/song/x/EToxUyFpcwQ", false, "", ""},
+		{"https://gaana.com/song/tum-hi-ho", false, "", ""},
+	}
+
+	j := &JioSaavnPlatform{}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := j.IsValid(tt.url); got != tt.valid {
+				t.Fatalf("IsValid = %v, want %v", got, tt.valid)
+			}
+			m := jiosaavnURLRegex.FindStringSubmatch(tt.url)
+			if tt.kind == "" {
+				if m != nil {
+					t.Fatalf("matched %q, want no match", m)
+				}
+				return
+			}
+			if m == nil || m[1] != tt.kind || m[2] != tt.token {
+				t.Fatalf("match = %q, want %s %s", m, tt.kind, tt.token)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+)
+
+// LiveChannelPlatform plays the live broadcast of a streaming channel.
+// Nothing is downloaded, the resolved stream url goes to ffmpeg directly.
+type LiveChannelPlatform struct {
+	name    state.PlatformName
+	base    string // channel url without the channel name
+	pattern *regexp.Regexp
+}
+
+const (
+	PlatformTwitch state.PlatformName = "Twitch"
+	PlatformKick   state.PlatformName = "Kick"
+)
+
+func init() {
+	Register(82, &LiveChannelPlatform{
+		name: PlatformTwitch,
+		base: "https://www.twitch.tv/",
+		pattern: regexp.MustCompile(
+			`(?i)^(https?://)?(www\.|m\.)?twitch\.tv/([a-z0-9_]{3,25})/?(\?.*)?$`,
+		),
+	})
+	Register(81, &LiveChannelPlatform{
+		name: PlatformKick,
+		base: "https://kick.com/",
+		pattern: regexp.MustCompile(
+			`(?i)^(https?://)?(www\.)?kick\.com/([a-z0-9_-]{3,25})/?(\?.*)?$`,
+		),
+	})
+}
+
+func (l *LiveChannelPlatform) Name() state.PlatformName {
+	return l.name
+}
+
+func (l *LiveChannelPlatform) IsValid(query string) bool {
+	return l.pattern.MatchString(strings.TrimSpace(query))
+}
+
+func (l *LiveChannelPlatform) GetTracks(
+	query string,
+	video bool,
+) ([]*state.Track, error) {
+	match := l.pattern.FindStringSubmatch(strings.TrimSpace(query))
+	if match == nil {
+		return nil, fmt.Errorf("invalid %s channel url", l.name)
+	}
+	channel := strings.ToLower(match[3])
+	url := l.base + channel
+
+	gologging.InfoF("%s: Fetching live status of %s", l.name, channel)
+
+	info, err := extractYtDlpInfo(string(l.name), url)
+	if err != nil || !info.IsLive {
+		return nil, fmt.Errorf("%s is not live right now", channel)
+	}
+
+	// yt-dlp names Twitch streams after the channel and keeps the
+	// broadcast title in the description.
+	title := info.Title
+	if l.name == PlatformTwitch && info.Description != "" {
+		title = info.Description
+	}
+	if title == "" {
+		title = channel
+	} else if !strings.EqualFold(title, info.Uploader) {
+		title = artistTitle(info.Uploader, title)
+	}
+
+	return []*state.Track{{
+		ID:      strings.ToLower(string(l.name)) + "_" + channel,
+		Title:   title,
+		Artwork: info.Thumbnail,
+		URL:     url,
+		Source:  l.name,
+		Video:   video,
+		IsLive:  true,
+	}}, nil
+}
+
+func (l *LiveChannelPlatform) IsDownloadSupported(
+	source state.PlatformName,
+) bool {
+	return source == l.name
+}
+
+func (l *LiveChannelPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	_ *telegram.NewMessage,
+) (string, error) {
+	return downloadYtDlp(ctx, string(l.name), track)
+}
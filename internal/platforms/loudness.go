@@ -0,0 +1,121 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+const (
+	// maxGain bounds how far a track is turned up or down, in dB.
+	maxGain = 20.0
+	// peakCeiling is the true peak (dBTP) a boosted track may reach.
+	peakCeiling = -1.0
+	// analysisTimeout bounds a single loudness measurement.
+	analysisTimeout = 5 * time.Minute
+)
+
+var (
+	// analysisSlots bounds the ffmpeg measurements running at once.
+	analysisSlots = make(chan struct{}, 2)
+	// analyzing holds the IDs of the tracks being measured.
+	analyzing sync.Map
+)
+
+// OnTrackGain is called once the gain of a track has been measured, so
+// streams that started without it can be restarted.
+var OnTrackGain func(trackID string) // overwritten from main.go
+
+// trackGains caches, per track ID, the gain in dB that brings the track
+// to config.LoudnessTarget.
+var trackGains = utils.NewCache[string, float64](24 * time.Hour)
+
+// TrackGain returns the loudness gain measured for trackID when it was
+// downloaded. ok is false when the track wasn't analysed.
+func TrackGain(trackID string) (float64, bool) {
+	return trackGains.Get(trackID)
+}
+
+// analyzeLoudness measures the downloaded file of track in the background
+// and caches its gain, so the download isn't held up by a full decode.
+// Queued and prefetched tracks are measured before they play; a track that
+// is already playing is restarted with the gain through OnTrackGain. It
+// does nothing unless NORMALIZE_LOUDNESS is on.
+func analyzeLoudness(ctx context.Context, track *state.Track, path string) {
+	if !config.NormalizeLoudness || track.IsLive || track.ID == "" ||
+		strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return
+	}
+	if _, ok := trackGains.Get(track.ID); ok {
+		return
+	}
+	if _, busy := analyzing.LoadOrStore(track.ID, struct{}{}); busy {
+		return
+	}
+
+	// the download's context usually ends as soon as it returns
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), analysisTimeout)
+	go func() {
+		defer cancel()
+		defer analyzing.Delete(track.ID)
+
+		select {
+		case analysisSlots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-analysisSlots }()
+
+		measureLoudness(ctx, track.ID, path)
+	}()
+}
+
+func measureLoudness(ctx context.Context, trackID, path string) {
+	start := time.Now()
+	l, err := utils.GetLoudnessByFFmpeg(ctx, path)
+	if err != nil {
+		gologging.WarnF("Loudness analysis of %s failed: %v", trackID, err)
+		return
+	}
+
+	gain := config.LoudnessTarget - l.Integrated
+	// don't push the peaks into clipping
+	gain = min(gain, peakCeiling-l.TruePeak)
+	gain = min(max(gain, -maxGain), maxGain)
+
+	trackGains.Set(trackID, gain)
+	if OnTrackGain != nil {
+		OnTrackGain(trackID)
+	}
+	gologging.DebugF(
+		"Loudness of %s: %.1f LUFS, %.1f dBTP, gain %.1f dB (%s)",
+		trackID, l.Integrated, l.TruePeak, gain, time.Since(start).Round(time.Millisecond),
+	)
+}
@@ -0,0 +1,94 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	state "main/internal/core/models"
+)
+
+// DownloadBuckets are the upper bounds, in seconds, of the download
+// latency histogram.
+var DownloadBuckets = []float64{1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// DownloadStats are the download attempts of one platform since start.
+type DownloadStats struct {
+	Platform state.PlatformName
+	Success  int64
+	Failure  int64
+	// Buckets counts successful downloads per DownloadBuckets bound,
+	// cumulative like a Prometheus histogram.
+	Buckets []int64
+	Seconds float64 // total time of successful downloads
+}
+
+var (
+	downloadStatsMu sync.Mutex
+	downloadStats   = map[state.PlatformName]*DownloadStats{}
+)
+
+func recordDownload(name state.PlatformName, took time.Duration, err error) {
+	downloadStatsMu.Lock()
+	defer downloadStatsMu.Unlock()
+
+	s, ok := downloadStats[name]
+	if !ok {
+		s = &DownloadStats{
+			Platform: name,
+			Buckets:  make([]int64, len(DownloadBuckets)),
+		}
+		downloadStats[name] = s
+	}
+
+	if err != nil {
+		s.Failure++
+		return
+	}
+
+	s.Success++
+	s.Seconds += took.Seconds()
+	for i, bound := range DownloadBuckets {
+		if took.Seconds() <= bound {
+			s.Buckets[i]++
+		}
+	}
+}
+
+// GetDownloadStats returns a copy of the download stats of every
+// platform that was tried at least once, sorted by platform name.
+func GetDownloadStats() []DownloadStats {
+	downloadStatsMu.Lock()
+	defer downloadStatsMu.Unlock()
+
+	list := make([]DownloadStats, 0, len(downloadStats))
+	for _, s := range downloadStats {
+		c := *s
+		c.Buckets = append([]int64(nil), s.Buckets...)
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Platform < list[j].Platform
+	})
+	return list
+}
@@ -0,0 +1,147 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+type MixcloudPlatform struct {
+	name state.PlatformName
+}
+
+var (
+	mixcloudLinkRegex = regexp.MustCompile(
+		`(?i)^(https?://)?(www\.|m\.)?mixcloud\.com/([^/?#]+)/([^/?#]+)`,
+	)
+	mixcloudCache = utils.NewCache[string, []*state.Track](1 * time.Hour)
+)
+
+const PlatformMixcloud state.PlatformName = "Mixcloud"
+
+func init() {
+	Register(83, &MixcloudPlatform{
+		name: PlatformMixcloud,
+	})
+}
+
+func (mc *MixcloudPlatform) Name() state.PlatformName {
+	return mc.name
+}
+
+func (mc *MixcloudPlatform) IsValid(query string) bool {
+	return mixcloudLinkRegex.MatchString(strings.TrimSpace(query))
+}
+
+func (mc *MixcloudPlatform) GetTracks(
+	query string,
+	_ bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+
+	cacheKey := "mixcloud:" + strings.ToLower(query)
+	if cached, ok := mixcloudCache.Get(cacheKey); ok {
+		gologging.Debug("Mixcloud: Using cached tracks")
+		return cached, nil
+	}
+
+	gologging.InfoF("Mixcloud: Fetching metadata for %s", query)
+
+	info, err := extractYtDlpInfo("Mixcloud", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []*state.Track
+	if len(info.Entries) > 0 {
+		for i := range info.Entries {
+			if t := mc.infoToTrack(&info.Entries[i]); t != nil {
+				tracks = append(tracks, t)
+			}
+		}
+	} else if t := mc.infoToTrack(info); t != nil {
+		tracks = append(tracks, t)
+	}
+
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no playable tracks found")
+	}
+
+	mixcloudCache.Set(cacheKey, tracks)
+	gologging.InfoF("Mixcloud: Extracted %d track(s)", len(tracks))
+	return tracks, nil
+}
+
+func (mc *MixcloudPlatform) IsDownloadSupported(
+	source state.PlatformName,
+) bool {
+	return source == PlatformMixcloud
+}
+
+func (mc *MixcloudPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	_ *telegram.NewMessage,
+) (string, error) {
+	return downloadYtDlp(ctx, "Mixcloud", track)
+}
+
+func (mc *MixcloudPlatform) infoToTrack(info *ytdlpInfo) *state.Track {
+	url := info.pageURL()
+	match := mixcloudLinkRegex.FindStringSubmatch(url)
+	if match == nil {
+		return nil
+	}
+
+	// Entries of user pages and playlists only carry the url, the mix
+	// is named by its slug then.
+	id, title := info.ID, info.Title
+	if id == "" {
+		id = match[3] + "_" + match[4]
+	}
+	if title == "" {
+		title = strings.ReplaceAll(match[4], "-", " ")
+	}
+
+	return &state.Track{
+		ID:       "mixcloud_" + id,
+		Title:    title,
+		Duration: int(info.Duration),
+		Artwork:  info.Thumbnail,
+		URL:      url,
+		Source:   PlatformMixcloud,
+		IsLive:   info.IsLive,
+	}
+}
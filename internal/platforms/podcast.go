@@ -0,0 +1,436 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+	"resty.dev/v3"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// PodcastPlatform plays the episodes of RSS and Atom podcast feeds. A feed
+// on its own resolves to its latest episode; /play offers the others in a
+// picker.
+type PodcastPlatform struct {
+	name state.PlatformName
+}
+
+// PodcastFeed is a parsed feed, Episodes are ordered newest first.
+type PodcastFeed struct {
+	Title    string
+	Artwork  string
+	Episodes []*state.Track
+}
+
+var (
+	// feed urls can't be told apart from other links for sure, these are
+	// the usual shapes: feeds.* / rss.* hosts or .rss, .xml, /feed, /rss paths
+	podcastFeedRegex = regexp.MustCompile(
+		`(?i)^https?://([^/?#]*\b(feeds?|rss)\b[^/?#]*(/|$)|[^?#]*(\.rss|\.xml|\.atom|/feed|/rss)/?([?#]|$))`,
+	)
+	applePodcastRegex = regexp.MustCompile(
+		`(?i)^https?://podcasts\.apple\.com/(?:[a-z]{2}/)?podcast/(?:[^/?]+/)?id(\d+)`,
+	)
+	podcastCache = utils.NewCache[string, *PodcastFeed](30 * time.Minute)
+
+	podcastDateLayouts = []string{
+		time.RFC1123Z,
+		time.RFC1123,
+		"Mon, 2 Jan 2006 15:04:05 -0700",
+		"Mon, 2 Jan 2006 15:04:05 MST",
+		"2 Jan 2006 15:04:05 -0700",
+		time.RFC3339,
+	}
+)
+
+const (
+	PlatformPodcast state.PlatformName = "Podcast"
+
+	itunesNS = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+)
+
+func init() {
+	Register(75, &PodcastPlatform{
+		name: PlatformPodcast,
+	})
+}
+
+// IsPodcastFeed reports whether url looks like a podcast feed or an Apple
+// Podcasts show.
+func IsPodcastFeed(url string) bool {
+	url = strings.TrimSpace(url)
+	return podcastFeedRegex.MatchString(url) || applePodcastRegex.MatchString(url)
+}
+
+func (p *PodcastPlatform) Name() state.PlatformName {
+	return p.name
+}
+
+func (p *PodcastPlatform) IsValid(query string) bool {
+	return IsPodcastFeed(query)
+}
+
+func (p *PodcastPlatform) GetTracks(
+	query string,
+	_ bool,
+) ([]*state.Track, error) {
+	feed, err := GetPodcastFeed(query)
+	if err != nil {
+		return nil, err
+	}
+	// a copy, the cached feed is shared by every chat playing it
+	episode := *feed.Episodes[0]
+	return []*state.Track{&episode}, nil
+}
+
+func (p *PodcastPlatform) IsDownloadSupported(
+	source state.PlatformName,
+) bool {
+	return source == PlatformPodcast
+}
+
+func (p *PodcastPlatform) Download(
+	ctx context.Context,
+	track *state.Track,
+	_ *telegram.NewMessage,
+) (string, error) {
+	if cached, err := checkDownloadedFile(track.ID); err == nil {
+		gologging.InfoF("Podcast: Using cached file for %s", track.ID)
+		return cached, nil
+	}
+
+	if err := ensureDownloadsDir(); err != nil {
+		return "", fmt.Errorf("failed to create downloads directory: %w", err)
+	}
+
+	ext := strings.ToLower(path.Ext(strings.SplitN(track.URL, "?", 2)[0]))
+	if ext == "" || len(ext) > 5 {
+		ext = ".mp3"
+	}
+	filePath := filepath.Join("downloads", track.ID+ext)
+
+	gologging.InfoF("Podcast: Downloading %s", track.Title)
+
+	client := resty.New().SetHeader("User-Agent", metadataUserAgent)
+	defer client.Close()
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetOutputFileName(filePath).
+		Get(track.URL)
+	if err != nil {
+		os.Remove(filePath)
+		if errors.Is(err, context.Canceled) ||
+			errors.Is(err, context.DeadlineExceeded) {
+			return "", err
+		}
+		return "", fmt.Errorf("episode download failed: %w", err)
+	}
+	if resp.IsError() {
+		os.Remove(filePath)
+		return "", fmt.Errorf("episode download failed with status: %d", resp.StatusCode())
+	}
+
+	return filePath, nil
+}
+
+// GetPodcastFeed fetches and parses the feed at url. Apple Podcasts links
+// are resolved to the show's feed first.
+func GetPodcastFeed(url string) (*PodcastFeed, error) {
+	url = strings.TrimSpace(url)
+
+	cacheKey := "podcast:" + url
+	if cached, ok := podcastCache.Get(cacheKey); ok {
+		return cached, nil
+	}
+
+	client := newMetadataClient()
+	defer client.Close()
+
+	feedURL := url
+	if m := applePodcastRegex.FindStringSubmatch(url); m != nil {
+		var err error
+		if feedURL, err = applePodcastFeedURL(client, m[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.R().Get(feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode())
+	}
+
+	feed, err := ParsePodcastFeed(resp.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(feed.Episodes) == 0 {
+		return nil, errors.New("the feed has no playable episodes")
+	}
+
+	podcastCache.Set(cacheKey, feed)
+	return feed, nil
+}
+
+// applePodcastFeedURL looks up the feed of an Apple Podcasts show id.
+func applePodcastFeedURL(client *resty.Client, id string) (string, error) {
+	resp, err := client.R().
+		SetQueryParam("id", id).
+		SetQueryParam("entity", "podcast").
+		Get(itunesLookupURL)
+	if err != nil {
+		return "", fmt.Errorf("Apple Podcasts lookup failed: %w", err)
+	}
+
+	var body struct {
+		Results []struct {
+			FeedURL string `json:"feedUrl"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(resp.Bytes(), &body); err != nil {
+		return "", fmt.Errorf("failed to parse Apple Podcasts lookup: %w", err)
+	}
+	for _, r := range body.Results {
+		if r.FeedURL != "" {
+			return r.FeedURL, nil
+		}
+	}
+	return "", errors.New("the show has no public feed")
+}
+
+type rssFeed struct {
+	Channel struct {
+		ITunesImage struct {
+			Href string `xml:"href,attr"`
+		} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+		Image struct {
+			URL string `xml:"url"`
+		} `xml:"image"`
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	// itunes:title would otherwise land in Title
+	ITunesTitle string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+	Title       string `xml:"title"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Enclosure   struct {
+		URL  string `xml:"url,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"enclosure"`
+	Duration string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Image    struct {
+		Href string `xml:"href,attr"`
+	} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+}
+
+type atomFeed struct {
+	Title   string      `xml:"title"`
+	Logo    string      `xml:"logo"`
+	Icon    string      `xml:"icon"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string `xml:"id"`
+	Title     string `xml:"title"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Links     []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"link"`
+	Duration string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+}
+
+// podcastEpisode is an episode with its publish time, for sorting.
+type podcastEpisode struct {
+	track     *state.Track
+	published time.Time
+}
+
+// ParsePodcastFeed reads an RSS 2.0 or Atom feed. Entries without an audio
+// or video enclosure are left out.
+func ParsePodcastFeed(data []byte) (*PodcastFeed, error) {
+	root, err := feedRoot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &PodcastFeed{}
+	var episodes []podcastEpisode
+
+	switch root {
+	case "rss":
+		var rss rssFeed
+		if err := xml.Unmarshal(data, &rss); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		}
+		ch := &rss.Channel
+		feed.Title = strings.TrimSpace(ch.Title)
+		feed.Artwork = utils.IfElse(ch.ITunesImage.Href != "", ch.ITunesImage.Href, ch.Image.URL)
+
+		for _, it := range ch.Items {
+			if it.Enclosure.URL == "" || !isMediaType(it.Enclosure.Type) {
+				continue
+			}
+			title := utils.IfElse(it.Title != "", it.Title, it.ITunesTitle)
+			episodes = append(episodes, podcastEpisode{
+				track: podcastTrack(
+					utils.IfElse(it.GUID != "", it.GUID, it.Enclosure.URL),
+					title,
+					it.Enclosure.URL,
+					utils.IfElse(it.Image.Href != "", it.Image.Href, feed.Artwork),
+					parseEpisodeDuration(it.Duration),
+				),
+				published: parseFeedDate(it.PubDate),
+			})
+		}
+	case "feed":
+		var atom atomFeed
+		if err := xml.Unmarshal(data, &atom); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+		}
+		feed.Title = strings.TrimSpace(atom.Title)
+		feed.Artwork = utils.IfElse(atom.Logo != "", atom.Logo, atom.Icon)
+
+		for _, e := range atom.Entries {
+			var media string
+			for _, l := range e.Links {
+				if l.Rel == "enclosure" && isMediaType(l.Type) {
+					media = l.Href
+					break
+				}
+			}
+			if media == "" {
+				continue
+			}
+			episodes = append(episodes, podcastEpisode{
+				track: podcastTrack(
+					utils.IfElse(e.ID != "", e.ID, media),
+					e.Title,
+					media,
+					feed.Artwork,
+					parseEpisodeDuration(e.Duration),
+				),
+				published: parseFeedDate(utils.IfElse(e.Published != "", e.Published, e.Updated)),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("not a podcast feed: <%s>", root)
+	}
+
+	// feeds are usually newest first already, but not always
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].published.After(episodes[j].published)
+	})
+	for _, e := range episodes {
+		feed.Episodes = append(feed.Episodes, e.track)
+	}
+	return feed, nil
+}
+
+// feedRoot returns the local name of the document's root element.
+func feedRoot(data []byte) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", errors.New("not a valid feed")
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func podcastTrack(guid, title, url, artwork string, duration int) *state.Track {
+	sum := sha1.Sum([]byte(guid))
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = path.Base(strings.SplitN(url, "?", 2)[0])
+	}
+	return &state.Track{
+		ID:       "podcast_" + hex.EncodeToString(sum[:8]),
+		Title:    title,
+		Duration: duration,
+		Artwork:  strings.TrimSpace(artwork),
+		URL:      strings.TrimSpace(url),
+		Source:   PlatformPodcast,
+	}
+}
+
+// isMediaType accepts audio and video enclosures, and ones without a type.
+func isMediaType(mime string) bool {
+	mime = strings.ToLower(mime)
+	return mime == "" || strings.HasPrefix(mime, "audio/") || strings.HasPrefix(mime, "video/")
+}
+
+// parseEpisodeDuration reads itunes:duration, given either in seconds or
+// as [HH:]MM:SS.
+func parseEpisodeDuration(s string) int {
+	total := 0
+	for _, part := range strings.Split(strings.TrimSpace(s), ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + int(n)
+	}
+	return total
+}
+
+func parseFeedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range podcastDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,287 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"resty.dev/v3"
+
+	state "main/internal/core/models"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// episodeID is the track ID ParsePodcastFeed gives an episode with guid.
+func episodeID(guid string) string {
+	return podcastTrack(guid, "", "", "", 0).ID
+}
+
+func checkEpisodes(t *testing.T, got, want []*state.Track) {
+	t.Helper()
+	if len(got) != len(want) {
+		for _, e := range got {
+			t.Logf("got episode %+v", *e)
+		}
+		t.Fatalf("got %d episodes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("episode %d = %+v, want %+v", i, *got[i], *want[i])
+		}
+	}
+}
+
+func TestParsePodcastFeedRSS(t *testing.T) {
+	feed, err := ParsePodcastFeed(readFixture(t, "podcast_rss.xml"))
+	if err != nil {
+		t.Fatalf("ParsePodcastFeed: %v", err)
+	}
+
+	if feed.Title != "The Test Show" {
+		t.Errorf("title = %q, want %q", feed.Title, "The Test Show")
+	}
+	if feed.Artwork != "https://example.com/show.jpg" {
+		t.Errorf("artwork = %q, want the itunes:image", feed.Artwork)
+	}
+
+	// newest first, undated last, entries without audio left out
+	checkEpisodes(t, feed.Episodes, []*state.Track{
+		{
+			ID:       episodeID("ep-3"),
+			Title:    "Episode 3",
+			Duration: 95,
+			Artwork:  "https://example.com/ep3.jpg",
+			URL:      "https://example.com/ep3.m4a",
+			Source:   PlatformPodcast,
+		},
+		{
+			ID:       episodeID("https://example.com/ep2.mp3?source=rss"),
+			Title:    "Episode 2",
+			Duration: 12*60 + 34,
+			Artwork:  "https://example.com/show.jpg",
+			URL:      "https://example.com/ep2.mp3?source=rss",
+			Source:   PlatformPodcast,
+		},
+		{
+			ID:       episodeID("ep-1"),
+			Title:    "Episode 1",
+			Duration: 3600 + 2*60 + 3,
+			Artwork:  "https://example.com/show.jpg",
+			URL:      "https://example.com/ep1.mp3",
+			Source:   PlatformPodcast,
+		},
+		{
+			ID:      episodeID("ep-0"),
+			Title:   "ep0.mp3",
+			Artwork: "https://example.com/show.jpg",
+			URL:     "https://example.com/media/ep0.mp3?token=abc",
+			Source:  PlatformPodcast,
+		},
+	})
+}
+
+func TestParsePodcastFeedAtom(t *testing.T) {
+	feed, err := ParsePodcastFeed(readFixture(t, "podcast_atom.xml"))
+	if err != nil {
+		t.Fatalf("ParsePodcastFeed: %v", err)
+	}
+
+	if feed.Title != "Atom Show" {
+		t.Errorf("title = %q, want %q", feed.Title, "Atom Show")
+	}
+	if feed.Artwork != "https://example.com/logo.png" {
+		t.Errorf("artwork = %q, want the logo", feed.Artwork)
+	}
+
+	checkEpisodes(t, feed.Episodes, []*state.Track{
+		{
+			ID:      episodeID("urn:atom:2"),
+			Title:   "Second",
+			Artwork: "https://example.com/logo.png",
+			URL:     "https://example.com/second.mp4",
+			Source:  PlatformPodcast,
+		},
+		{
+			ID:       episodeID("urn:atom:1"),
+			Title:    "First",
+			Duration: 30 * 60,
+			Artwork:  "https://example.com/logo.png",
+			URL:      "https://example.com/first.mp3",
+			Source:   PlatformPodcast,
+		},
+	})
+}
+
+func TestParsePodcastFeedInvalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"not xml at all",
+		"<html><body>hello</body></html>",
+	} {
+		if _, err := ParsePodcastFeed([]byte(data)); err == nil {
+			t.Errorf("ParsePodcastFeed(%q) succeeded", data)
+		}
+	}
+}
+
+func TestParseEpisodeDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"95", 95},
+		{" 60 ", 60},
+		{"12.7", 12},
+		{"12:34", 754},
+		{"1:02:03", 3723},
+		{"01:00:00", 3600},
+		{"", 0},
+		{"soon", 0},
+		{"1:xx", 0},
+	}
+	for _, tt := range tests {
+		if got := parseEpisodeDuration(tt.in); got != tt.want {
+			t.Errorf("parseEpisodeDuration(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+// rewriteTransport sends every request to target, whatever its URL.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestApplePodcastFeedURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "feed url",
+			body: `{"resultCount":2,"results":[{"collectionId":1},{"feedUrl":"https://feeds.example.com/show"}]}`,
+			want: "https://feeds.example.com/show",
+		},
+		{"no public feed", `{"resultCount":1,"results":[{"collectionId":1}]}`, "", true},
+		{"no results", `{"resultCount":0,"results":[]}`, "", true},
+		{"not json", `<html></html>`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			target, _ := url.Parse(srv.URL)
+			client := resty.New().SetTransport(rewriteTransport{target})
+			defer client.Close()
+
+			got, err := applePodcastFeedURL(client, "12345")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applePodcastFeedURL error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("applePodcastFeedURL = %q, want %q", got, tt.want)
+			}
+			if query.Get("id") != "12345" || query.Get("entity") != "podcast" {
+				t.Fatalf("lookup query = %v, want id=12345 and entity=podcast", query)
+			}
+		})
+	}
+}
+
+func TestApplePodcastRegex(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://podcasts.apple.com/us/podcast/the-show/id1200361736", "1200361736"},
+		{"https://podcasts.apple.com/podcast/id1200361736?i=1000", "1200361736"},
+		{"https://podcasts.apple.com/gb/podcast/id42", "42"},
+		{"https://music.apple.com/us/album/x/123", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if m := applePodcastRegex.FindStringSubmatch(tt.url); m != nil {
+			got = m[1]
+		}
+		if got != tt.want {
+			t.Errorf("show id of %s = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestPodcastGetTracksReturnsCopy(t *testing.T) {
+	feed := readFixture(t, "podcast_rss.xml")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(feed)
+	}))
+	defer srv.Close()
+
+	feedURL := srv.URL + "/feed.xml"
+	p := &PodcastPlatform{name: PlatformPodcast}
+
+	tracks, err := p.GetTracks(feedURL, false)
+	if err != nil {
+		t.Fatalf("GetTracks: %v", err)
+	}
+	if len(tracks) != 1 || tracks[0].ID != episodeID("ep-3") {
+		t.Fatalf("GetTracks = %+v, want the latest episode", tracks)
+	}
+
+	// callers set requester and chat fields on the track they get
+	tracks[0].Requester = "someone"
+	tracks[0].Title = "changed"
+
+	cached, err := GetPodcastFeed(feedURL)
+	if err != nil {
+		t.Fatalf("GetPodcastFeed: %v", err)
+	}
+	if cached.Episodes[0] == tracks[0] || cached.Episodes[0].Title != "Episode 3" ||
+		cached.Episodes[0].Requester != "" {
+		t.Fatalf("cached episode was changed through GetTracks: %+v", *cached.Episodes[0])
+	}
+}
@@ -0,0 +1,117 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/zmb3/spotify/v2"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+)
+
+// maxRelatedDuration leaves out long mixes and compilations.
+const maxRelatedDuration = 15 * 60
+
+// GetRelatedTracks suggests tracks similar to t, best matches first.
+// Spotify tracks use the artist's top tracks when Spotify is configured,
+// everything else falls back to a YouTube search.
+func GetRelatedTracks(t *state.Track) ([]*state.Track, error) {
+	if t == nil {
+		return nil, errors.New("no track to base suggestions on")
+	}
+
+	if t.Source == PlatformSpotify &&
+		config.SpotifyClientID != "" && config.SpotifyClientSecret != "" {
+		if tracks, err := spotifyRelated(t); err == nil && len(tracks) > 0 {
+			return tracks, nil
+		}
+	}
+
+	return youtubeRelated(t)
+}
+
+func spotifyRelated(t *state.Track) ([]*state.Track, error) {
+	var s *SpotifyPlatform
+	for _, p := range GetOrderedPlatforms() {
+		if sp, ok := p.(*SpotifyPlatform); ok {
+			s = sp
+			break
+		}
+	}
+	if s == nil {
+		return nil, errors.New("spotify platform not registered")
+	}
+	if err := s.ensureClient(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	full, err := s.client.GetTrack(ctx, spotify.ID(t.ID))
+	if err != nil {
+		return nil, err
+	}
+	if len(full.Artists) == 0 {
+		return nil, errors.New("track has no artist")
+	}
+
+	tracks, err := s.getArtistTopTracks(ctx, full.Artists[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	return filterRelated(t, tracks), nil
+}
+
+func youtubeRelated(t *state.Track) ([]*state.Track, error) {
+	query := cleanTitle(t.Title)
+	// "Artist - Song" titles: other songs of the artist are a better
+	// match than covers and remixes of the same song.
+	if artist, _, ok := strings.Cut(query, " - "); ok && artist != "" {
+		query = artist
+	}
+	if query == "" {
+		return nil, errors.New("no title to search for")
+	}
+
+	yt := &YouTubePlatform{}
+	tracks, err := yt.VideoSearch(query)
+	if err != nil {
+		return nil, err
+	}
+	return filterRelated(t, tracks), nil
+}
+
+func filterRelated(t *state.Track, tracks []*state.Track) []*state.Track {
+	result := make([]*state.Track, 0, len(tracks))
+	for _, r := range tracks {
+		if r == nil || r.ID == t.ID || r.IsLive ||
+			r.Duration == 0 || r.Duration > maxRelatedDuration {
+			continue
+		}
+		clone := *r
+		clone.Video = t.Video
+		result = append(result, &clone)
+	}
+	return result
+}
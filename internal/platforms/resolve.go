@@ -0,0 +1,146 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Laky-64/gologging"
+	"github.com/amarnathcjd/gogram/telegram"
+	"resty.dev/v3"
+
+	state "main/internal/core/models"
+)
+
+// metadataUserAgent is sent by the metadata-only platforms, some of the
+// services reject requests without a browser like agent.
+const metadataUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36"
+
+func newMetadataClient() *resty.Client {
+	return resty.New().
+		SetTimeout(15*time.Second).
+		SetHeader("User-Agent", metadataUserAgent)
+}
+
+// artistTitle joins an artist and a title the way music videos are
+// usually named on YouTube, which makes the later search more accurate.
+func artistTitle(artist, title string) string {
+	artist = strings.TrimSpace(artist)
+	title = strings.TrimSpace(title)
+	if artist == "" {
+		return title
+	}
+	return artist + " - " + title
+}
+
+// downloadViaYouTube finds track on YouTube by its title and downloads the
+// match with the first YouTube capable downloader. Platforms that only
+// provide metadata (Spotify, Apple Music, ...) download through it; source
+// only names them in logs.
+func downloadViaYouTube(
+	ctx context.Context,
+	track *state.Track,
+	mystic *telegram.NewMessage,
+	source string,
+) (string, error) {
+	yt := &YouTubePlatform{}
+
+	clean := cleanTitle(track.Title)
+	trimmed := trimTitleLen(clean, 25, 40)
+
+	var queries []string
+
+	if clean != "" {
+		queries = append(queries, clean)
+	}
+
+	if clean != "" && trimmed != "" && trimmed != clean {
+		queries = append(queries, clean+" "+trimmed)
+	}
+
+	if trimTitleLen(track.Title, 25, 40) != "" {
+		queries = append(queries, trimTitleLen(track.Title, 25, 40))
+	}
+
+	var ytTrack *state.Track
+
+	for i, q := range queries {
+		gologging.DebugF(
+			"[%s→YouTube] Search attempt %d: %q",
+			source,
+			i+1,
+			q,
+		)
+
+		ytTracks, err := yt.VideoSearch(q, true)
+		if err != nil || len(ytTracks) == 0 {
+			gologging.DebugF(
+				"[%s→YouTube] No result for %q (err=%v)",
+				source,
+				q,
+				err,
+			)
+			continue
+		}
+
+		ytTrack = ytTracks[0]
+		ytTrack.Video = track.Video
+
+		gologging.DebugF(
+			"[%s→YouTube] Match found using %q → %s",
+			source,
+			q,
+			ytTrack.URL,
+		)
+		break
+	}
+
+	if ytTrack == nil {
+		return "", errors.New("failed to find track on YouTube")
+	}
+
+	for _, p := range GetOrderedPlatforms() {
+		if p.IsDownloadSupported(PlatformYouTube) {
+			path, err := p.Download(ctx, ytTrack, mystic)
+			if err == nil {
+				gologging.InfoF(
+					"Downloaded %s track '%s' from YouTube: %s",
+					source,
+					track.Title,
+					ytTrack.URL,
+				)
+				return path, nil
+			}
+
+			gologging.DebugF(
+				"[%s→YouTube] Downloader %T failed: %v",
+				source,
+				p,
+				err,
+			)
+		}
+	}
+
+	return "", errors.New("no YouTube downloader available")
+}
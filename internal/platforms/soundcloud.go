@@ -30,6 +30,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -119,6 +120,36 @@ func (s *SoundCloudPlatform) GetTracks(
 	return tracks, nil
 }
 
+// Search returns the first limit SoundCloud tracks matching query.
+func (s *SoundCloudPlatform) Search(
+	query string,
+	limit int,
+	_ bool,
+) ([]*state.Track, error) {
+	query = strings.TrimSpace(query)
+	cacheKey := "search:" + strconv.Itoa(limit) + ":" + strings.ToLower(query)
+	if cached, ok := soundcloudCache.Get(cacheKey); ok {
+		return cached, nil
+	}
+
+	info, err := searchYtDlp(
+		string(PlatformSoundCloud),
+		"scsearch"+strconv.Itoa(limit)+":"+query,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []*state.Track
+	for i := range info.Entries {
+		tracks = append(tracks, s.infoToTrack(&info.Entries[i]))
+	}
+	if len(tracks) > 0 {
+		soundcloudCache.Set(cacheKey, tracks)
+	}
+	return tracks, nil
+}
+
 func (s *SoundCloudPlatform) IsDownloadSupported(
 	source state.PlatformName,
 ) bool {
@@ -278,6 +309,7 @@ func (s *SoundCloudPlatform) infoToTrack(info *ytdlpInfo) *state.Track {
 		URL:      info.URL,
 		Source:   PlatformSoundCloud,
 		Video:    false,
+		Channel:  info.Uploader,
 	}
 
 	return track
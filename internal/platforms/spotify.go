@@ -148,80 +148,7 @@ func (s *SpotifyPlatform) Download(
 	track *state.Track,
 	mystic *telegram.NewMessage,
 ) (string, error) {
-	yt := &YouTubePlatform{}
-
-	clean := cleanTitle(track.Title)
-	trimmed := trimTitleLen(clean, 25, 40)
-
-	var queries []string
-
-	if clean != "" {
-		queries = append(queries, clean)
-	}
-
-	if clean != "" && trimmed != "" && trimmed != clean {
-		queries = append(queries, clean+" "+trimmed)
-	}
-
-	if trimTitleLen(track.Title, 25, 40) != "" {
-		queries = append(queries, trimTitleLen(track.Title, 25, 40))
-	}
-
-	var ytTrack *state.Track
-
-	for i, q := range queries {
-		gologging.DebugF(
-			"[Spotify→YouTube] Search attempt %d: %q",
-			i+1,
-			q,
-		)
-
-		ytTracks, err := yt.VideoSearch(q, true)
-		if err != nil || len(ytTracks) == 0 {
-			gologging.DebugF(
-				"[Spotify→YouTube] No result for %q (err=%v)",
-				q,
-				err,
-			)
-			continue
-		}
-
-		ytTrack = ytTracks[0]
-		ytTrack.Video = track.Video
-
-		gologging.DebugF(
-			"[Spotify→YouTube] Match found using %q → %s",
-			q,
-			ytTrack.URL,
-		)
-		break
-	}
-
-	if ytTrack == nil {
-		return "", errors.New("failed to find track on YouTube")
-	}
-
-	for _, p := range GetOrderedPlatforms() {
-		if p.IsDownloadSupported(PlatformYouTube) {
-			path, err := p.Download(ctx, ytTrack, mystic)
-			if err == nil {
-				gologging.InfoF(
-					"Downloaded Spotify track '%s' from YouTube: %s",
-					track.Title,
-					ytTrack.URL,
-				)
-				return path, nil
-			}
-
-			gologging.DebugF(
-				"[Spotify→YouTube] Downloader %T failed: %v",
-				p,
-				err,
-			)
-		}
-	}
-
-	return "", errors.New("no YouTube downloader available")
+	return downloadViaYouTube(ctx, track, mystic, "Spotify")
 }
 
 // ensureClient initializes the Spotify client (once)
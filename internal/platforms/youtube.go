@@ -153,6 +153,22 @@ func (yp *YouTubePlatform) GetTracks(
 	return updateCached(tracks, video), nil
 }
 
+// Search returns the first limit results of a YouTube search.
+func (yp *YouTubePlatform) Search(
+	query string,
+	limit int,
+	video bool,
+) ([]*state.Track, error) {
+	tracks, err := yp.VideoSearch(strings.TrimSpace(query))
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) > limit {
+		tracks = tracks[:limit]
+	}
+	return updateCached(tracks, video), nil
+}
+
 func (yp *YouTubePlatform) IsDownloadSupported(source state.PlatformName) bool {
 	return false
 }
@@ -405,6 +421,7 @@ func parseSearchResults(node interface{}, tracks *[]*state.Track) {
 			id := safeString(vid["videoId"])
 			title := safeString(dig(vid, "title", "runs", 0, "text"))
 			thumb := safeString(dig(vid, "thumbnail", "thumbnails", 0, "url"))
+			channel := safeString(dig(vid, "ownerText", "runs", 0, "text"))
 			durationText := safeString(dig(vid, "lengthText", "simpleText"))
 
 			if durationText == "" {
@@ -419,6 +436,7 @@ func parseSearchResults(node interface{}, tracks *[]*state.Track) {
 				Artwork:  thumb,
 				Duration: duration,
 				Source:   PlatformYouTube,
+				Channel:  channel,
 			}
 			*tracks = append(*tracks, t)
 			youtubeCache.Set("track:"+t.ID, []*state.Track{t})
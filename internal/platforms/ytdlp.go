@@ -31,6 +31,7 @@ type ytdlpInfo struct {
 	Thumbnail   string      `json:"thumbnail"`
 	URL         string      `json:"webpage_url"`
 	OriginalURL string      `json:"original_url"`
+	EntryURL    string      `json:"url"`
 	Uploader    string      `json:"uploader"`
 	Description string      `json:"description"`
 	IsLive      bool        `json:"is_live"`
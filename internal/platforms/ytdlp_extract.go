@@ -0,0 +1,249 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package platforms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Laky-64/gologging"
+
+	state "main/internal/core/models"
+)
+
+// Shared yt-dlp helpers for the platforms that are extracted by yt-dlp
+// but are not YouTube (Bandcamp, Mixcloud, Twitch, Kick, SoundCloud search).
+
+// extractYtDlpInfo reads the metadata of url. Playlists are flattened, in
+// that case yt-dlp prints one JSON object per entry.
+func extractYtDlpInfo(prefix, url string) (*ytdlpInfo, error) {
+	return runYtDlpJSON(prefix, "--flat-playlist", url)
+}
+
+// searchYtDlp runs a yt-dlp search such as "scsearch5:query". Unlike
+// extractYtDlpInfo the results are extracted fully, flat search entries
+// have neither title nor duration. The results are always in Entries.
+func searchYtDlp(prefix, search string) (*ytdlpInfo, error) {
+	info, err := runYtDlpJSON(prefix, search)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Entries) == 0 {
+		info = &ytdlpInfo{Entries: []ytdlpInfo{*info}}
+	}
+	return info, nil
+}
+
+func runYtDlpJSON(prefix string, args ...string) (*ytdlpInfo, error) {
+	cmd := exec.Command("yt-dlp", append([]string{
+		"-j",
+		"--no-warnings",
+		"--no-check-certificate",
+	}, args...)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		gologging.ErrorF(
+			"%s: yt-dlp metadata extraction failed: %v\n%s",
+			prefix,
+			err,
+			stderr.String(),
+		)
+		return nil, fmt.Errorf("metadata extraction failed: %w", err)
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return nil, errors.New("no results")
+	}
+
+	lines := strings.Split(output, "\n")
+	if len(lines) == 1 {
+		var info ytdlpInfo
+		if err := json.Unmarshal([]byte(lines[0]), &info); err != nil {
+			gologging.ErrorF("%s: Failed to parse JSON: %v", prefix, err)
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		return &info, nil
+	}
+
+	var info ytdlpInfo
+	info.Entries = make([]ytdlpInfo, 0, len(lines))
+	for _, line := range lines {
+		var entry ytdlpInfo
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			gologging.ErrorF("%s: Failed to parse entry JSON: %v", prefix, err)
+			continue
+		}
+		info.Entries = append(info.Entries, entry)
+	}
+
+	if len(info.Entries) == 0 {
+		return nil, errors.New("no valid entries found in playlist")
+	}
+	return &info, nil
+}
+
+// pageURL returns the url a track should be downloaded from. Flattened
+// playlist entries only carry it in the url field.
+func (info *ytdlpInfo) pageURL() string {
+	if info.URL != "" {
+		return info.URL
+	}
+	if info.OriginalURL != "" {
+		return info.OriginalURL
+	}
+	return info.EntryURL
+}
+
+// downloadYtDlp fetches track for playback. Live tracks are never written
+// to disk, the direct stream url is returned instead so ffmpeg reads it
+// through its reconnecting network input.
+func downloadYtDlp(
+	ctx context.Context,
+	prefix string,
+	track *state.Track,
+) (string, error) {
+	if track.IsLive {
+		return ytdlpStreamURL(ctx, prefix, track)
+	}
+	return downloadYtDlpAudio(ctx, prefix, track)
+}
+
+// ytdlpStreamURL resolves the media url of a live track without
+// downloading anything.
+func ytdlpStreamURL(
+	ctx context.Context,
+	prefix string,
+	track *state.Track,
+) (string, error) {
+	format := "bestaudio/best"
+	if track.Video {
+		format = "best[height<=720]/best"
+	}
+
+	cmd := exec.CommandContext(ctx, "yt-dlp",
+		"-g",
+		"-f", format,
+		"--no-playlist",
+		"--no-warnings",
+		"--no-check-certificate",
+		track.URL,
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return "", ctx.Err()
+		}
+		gologging.ErrorF(
+			"%s: Failed to resolve stream url for %s: %v\n%s",
+			prefix,
+			track.URL,
+			err,
+			stderr.String(),
+		)
+		return "", fmt.Errorf("failed to resolve live stream: %w", err)
+	}
+
+	streamURL, _, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\n")
+	if streamURL == "" {
+		return "", errors.New("yt-dlp did not return a stream url")
+	}
+
+	gologging.InfoF("%s: Streaming live %s", prefix, track.URL)
+	return streamURL, nil
+}
+
+// downloadYtDlpAudio downloads the best audio of track as mp3, reusing an
+// earlier download of the same track.
+func downloadYtDlpAudio(
+	ctx context.Context,
+	prefix string,
+	track *state.Track,
+) (string, error) {
+	if path, err := checkDownloadedFile(track.ID); err == nil {
+		gologging.InfoF("%s: Using cached file for %s", prefix, track.ID)
+		return path, nil
+	}
+
+	if err := ensureDownloadsDir(); err != nil {
+		return "", fmt.Errorf("failed to create downloads directory: %w", err)
+	}
+
+	gologging.InfoF("%s: Downloading %s", prefix, track.Title)
+
+	filePath := filepath.Join("downloads", track.ID+".mp3")
+	cmd := exec.CommandContext(ctx, "yt-dlp",
+		"-f", "bestaudio/best",
+		"--extract-audio",
+		"--audio-format", "mp3",
+		"--audio-quality", "0",
+		"--no-playlist",
+		"-o", filePath,
+		"--no-warnings",
+		"--no-overwrites",
+		"--no-check-certificate",
+		"-q",
+		track.URL,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		os.Remove(filePath)
+
+		if errors.Is(err, context.Canceled) ||
+			errors.Is(ctx.Err(), context.Canceled) {
+			return "", context.Canceled
+		}
+
+		gologging.ErrorF(
+			"%s: yt-dlp download failed for %s: %v\n%s",
+			prefix,
+			track.URL,
+			err,
+			stderr.String(),
+		)
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+
+	if _, err := os.Stat(filePath); err != nil {
+		return "", fmt.Errorf("downloaded file not found: %w", err)
+	}
+
+	gologging.InfoF("%s: Successfully downloaded %s", prefix, track.Title)
+	return filePath, nil
+}
@@ -0,0 +1,127 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package radio
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Laky-64/gologging"
+
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// ErrNotFound is returned when no provider lists a matching station.
+var ErrNotFound = errors.New("no stations found")
+
+type providerEntry struct {
+	provider state.RadioProvider
+	priority int
+}
+
+var (
+	providers   []providerEntry
+	providersMu sync.RWMutex
+
+	cache = utils.NewCache[string, []*state.RadioStation](30 * time.Minute)
+)
+
+// Register adds a radio directory provider with the given priority.
+// Higher priority = listed first
+func Register(priority int, p state.RadioProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers = append(providers, providerEntry{provider: p, priority: priority})
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].priority > providers[j].priority
+	})
+}
+
+// Search asks the registered providers in order and returns up to limit
+// stations. Results of higher priority providers come first, a stream
+// listed twice is only kept once.
+func Search(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]*state.RadioStation, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || limit <= 0 {
+		return nil, ErrNotFound
+	}
+
+	key := strings.ToLower(query) + "|" + utils.IntToStr(limit)
+	if s, ok := cache.Get(key); ok {
+		return s, nil
+	}
+
+	providersMu.RLock()
+	list := make([]state.RadioProvider, len(providers))
+	for i, e := range providers {
+		list[i] = e.provider
+	}
+	providersMu.RUnlock()
+
+	var (
+		stations []*state.RadioStation
+		seen     = make(map[string]bool)
+	)
+	for _, p := range list {
+		found, err := p.Search(ctx, query, limit-len(stations))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if !errors.Is(err, ErrNotFound) {
+				gologging.DebugF("Radio provider %s failed: %v", p.Name(), err)
+			}
+			continue
+		}
+		for _, s := range found {
+			if s == nil || s.URL == "" || seen[s.URL] {
+				continue
+			}
+			seen[s.URL] = true
+			if s.Source == "" {
+				s.Source = p.Name()
+			}
+			stations = append(stations, s)
+		}
+		if len(stations) >= limit {
+			break
+		}
+	}
+
+	if len(stations) == 0 {
+		return nil, ErrNotFound
+	}
+	if len(stations) > limit {
+		stations = stations[:limit]
+	}
+	cache.Set(key, stations)
+	return stations, nil
+}
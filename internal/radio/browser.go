@@ -0,0 +1,128 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package radio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+)
+
+// BrowserProvider searches the community run Radio Browser directory.
+// https://api.radio-browser.info
+type BrowserProvider struct {
+	BaseURL string
+}
+
+type browserStation struct {
+	UUID        string `json:"stationuuid"`
+	Name        string `json:"name"`
+	URLResolved string `json:"url_resolved"`
+	URL         string `json:"url"`
+	Favicon     string `json:"favicon"`
+	Tags        string `json:"tags"`
+	CountryCode string `json:"countrycode"`
+	Codec       string `json:"codec"`
+	Bitrate     int    `json:"bitrate"`
+	LastCheckOK int    `json:"lastcheckok"`
+}
+
+func init() {
+	Register(50, &BrowserProvider{BaseURL: config.RadioBrowserURL})
+}
+
+func (p *BrowserProvider) Name() string {
+	return "Radio Browser"
+}
+
+func (p *BrowserProvider) Search(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]*state.RadioStation, error) {
+	if p.BaseURL == "" {
+		return nil, ErrNotFound
+	}
+
+	client := resty.New().
+		SetTimeout(15*time.Second).
+		SetHeader("User-Agent", "YukkiMusic (https://github.com/TheTeamVivek/YukkiMusic)")
+	defer client.Close()
+
+	var results []browserStation
+	resp, err := client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"name":       query,
+			"limit":      fmt.Sprint(limit),
+			"hidebroken": "true",
+			"order":      "clickcount",
+			"reverse":    "true",
+		}).
+		SetResult(&results).
+		Get(strings.TrimRight(p.BaseURL, "/") + "/json/stations/search")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("Radio Browser returned %s", resp.Status())
+	}
+
+	var stations []*state.RadioStation
+	for _, r := range results {
+		url := r.URLResolved
+		if url == "" {
+			url = r.URL
+		}
+		if url == "" || r.LastCheckOK == 0 {
+			continue
+		}
+
+		var tags []string
+		for _, t := range strings.Split(r.Tags, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+
+		stations = append(stations, &state.RadioStation{
+			ID:      r.UUID,
+			Name:    strings.TrimSpace(r.Name),
+			URL:     url,
+			Country: r.CountryCode,
+			Tags:    tags,
+			Codec:   r.Codec,
+			Bitrate: r.Bitrate,
+			Favicon: r.Favicon,
+		})
+	}
+
+	if len(stations) == 0 {
+		return nil, ErrNotFound
+	}
+	return stations, nil
+}
@@ -0,0 +1,121 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package radio
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNoMetadata is returned by WatchICY for streams that don't send
+// in-band metadata.
+var ErrNoMetadata = errors.New("stream has no ICY metadata")
+
+// WatchICY reads the Icecast/SHOUTcast stream at url, asking for in-band
+// metadata, and calls onTitle whenever the announced StreamTitle changes.
+// The audio itself is thrown away. It returns when ctx is done or the
+// stream ends or fails.
+func WatchICY(ctx context.Context, url string, onTitle func(string)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Icy-MetaData", "1")
+	req.Header.Set("User-Agent", "YukkiMusic (https://github.com/TheTeamVivek/YukkiMusic)")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("stream returned %s", resp.Status)
+	}
+
+	// audio bytes between two metadata blocks
+	metaint, _ := strconv.Atoi(resp.Header.Get("icy-metaint"))
+	if metaint <= 0 {
+		return ErrNoMetadata
+	}
+
+	r := bufio.NewReaderSize(resp.Body, 16*1024)
+	block := make([]byte, 255*16)
+	var last string
+	for {
+		if _, err := io.CopyN(io.Discard, r, int64(metaint)); err != nil {
+			return err
+		}
+		n, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+		if _, err := io.ReadFull(r, block[:int(n)*16]); err != nil {
+			return err
+		}
+
+		title := ParseStreamTitle(block[:int(n)*16])
+		if title != "" && title != last {
+			last = title
+			onTitle(title)
+		}
+	}
+}
+
+// ParseStreamTitle returns the StreamTitle of an ICY metadata block like
+// "StreamTitle='Artist - Song';", padded with zero bytes.
+func ParseStreamTitle(block []byte) string {
+	s := strings.TrimRight(string(block), "\x00")
+
+	const key = "StreamTitle='"
+	i := strings.Index(s, key)
+	if i < 0 {
+		return ""
+	}
+	s = s[i+len(key):]
+	// titles may contain quotes themselves, the field ends at the last
+	// "';" before the next field
+	if j := strings.Index(s, "';Stream"); j >= 0 {
+		s = s[:j]
+	} else if j := strings.LastIndex(s, "';"); j >= 0 {
+		s = s[:j]
+	}
+
+	if !utf8.ValidString(s) {
+		// older servers send Latin-1
+		runes := make([]rune, len(s))
+		for i := 0; i < len(s); i++ {
+			runes[i] = rune(s[i])
+		}
+		s = string(runes)
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,61 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package radio
+
+import "testing"
+
+// icyBlock pads s with zero bytes to a multiple of 16 like a server does.
+func icyBlock(s string) []byte {
+	b := []byte(s)
+	if pad := len(b) % 16; pad != 0 {
+		b = append(b, make([]byte, 16-pad)...)
+	}
+	return b
+}
+
+func TestParseStreamTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		block []byte
+		want  string
+	}{
+		{"plain", icyBlock("StreamTitle='Daft Punk - One More Time';"), "Daft Punk - One More Time"},
+		{"unpadded", []byte("StreamTitle='Daft Punk - One More Time';"), "Daft Punk - One More Time"},
+		{"with url", icyBlock("StreamTitle='Daft Punk - Aerodynamic';StreamUrl='https://example.com/art.jpg';"), "Daft Punk - Aerodynamic"},
+		{"quotes", icyBlock("StreamTitle='Guns N' Roses - Sweet Child O' Mine';StreamUrl='';"), "Guns N' Roses - Sweet Child O' Mine"},
+		{"quote before end", icyBlock("StreamTitle='Don't Stop Me Now';"), "Don't Stop Me Now"},
+		{"spaces", icyBlock("StreamTitle='   Massive Attack - Teardrop  ';"), "Massive Attack - Teardrop"},
+		{"latin-1", icyBlock("StreamTitle='Beyonc\xe9 - D\xe9j\xe0 Vu';"), "Beyoncé - Déjà Vu"},
+		{"utf-8", icyBlock("StreamTitle='Sigur Rós - Hoppípolla';"), "Sigur Rós - Hoppípolla"},
+		{"empty title", icyBlock("StreamTitle='';"), ""},
+		{"no title", icyBlock("StreamUrl='https://example.com';"), ""},
+		{"zeros", make([]byte, 32), ""},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseStreamTitle(tt.block); got != tt.want {
+				t.Fatalf("ParseStreamTitle(%q) = %q, want %q", tt.block, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package radio
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"main/internal/config"
+	state "main/internal/core/models"
+	"main/internal/utils"
+)
+
+// LocalProvider lists the stations of a curated YAML file (format in
+// README.md). The file is read on every search, so it can be edited while
+// the bot runs.
+type LocalProvider struct {
+	File string
+}
+
+type localStation struct {
+	Name    string   `yaml:"name"`
+	URL     string   `yaml:"url"`
+	Country string   `yaml:"country"`
+	Tags    []string `yaml:"tags"`
+	Codec   string   `yaml:"codec"`
+	Bitrate int      `yaml:"bitrate"`
+	Favicon string   `yaml:"favicon"`
+}
+
+func init() {
+	Register(100, &LocalProvider{File: config.RadioFile})
+}
+
+func (p *LocalProvider) Name() string {
+	return "local"
+}
+
+func (p *LocalProvider) Search(
+	_ context.Context,
+	query string,
+	limit int,
+) ([]*state.RadioStation, error) {
+	if p.File == "" {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(p.File)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	var list []localStation
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(strings.ToLower(query))
+	var stations []*state.RadioStation
+	for i, s := range list {
+		if s.Name == "" || s.URL == "" || !matchesAll(words, s) {
+			continue
+		}
+		stations = append(stations, &state.RadioStation{
+			ID:      "local_" + utils.IntToStr(i),
+			Name:    s.Name,
+			URL:     s.URL,
+			Country: strings.ToUpper(s.Country),
+			Tags:    s.Tags,
+			Codec:   s.Codec,
+			Bitrate: s.Bitrate,
+			Favicon: s.Favicon,
+		})
+		if len(stations) >= limit {
+			break
+		}
+	}
+
+	if len(stations) == 0 {
+		return nil, ErrNotFound
+	}
+	return stations, nil
+}
+
+// matchesAll reports whether every word of the query is found in the
+// station's name, tags or country.
+func matchesAll(words []string, s localStation) bool {
+	hay := strings.ToLower(s.Name + " " + strings.Join(s.Tags, " ") + " " + s.Country)
+	for _, w := range words {
+		if !strings.Contains(hay, w) {
+			return false
+		}
+	}
+	return true
+}
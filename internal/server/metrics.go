@@ -0,0 +1,190 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"main/internal/core"
+	"main/internal/platforms"
+)
+
+// Broadcast is the progress of the running or last broadcast.
+type Broadcast struct {
+	Active bool
+	Total  int
+	Done   int
+	Failed int
+}
+
+var GetBroadcast func() Broadcast // overwritten by modules.Init
+
+// metricWriter writes the Prometheus text exposition format.
+type metricWriter struct {
+	sb strings.Builder
+}
+
+// family starts a metric with its HELP and TYPE lines.
+func (w *metricWriter) family(name, kind, help string) {
+	fmt.Fprintf(&w.sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes one value; labels are name, value pairs.
+func (w *metricWriter) sample(name string, value float64, labels ...string) {
+	w.sb.WriteString(name)
+	if len(labels) > 0 {
+		w.sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.sb.WriteByte(',')
+			}
+			w.sb.WriteString(labels[i] + "=" + strconv.Quote(labels[i+1]))
+		}
+		w.sb.WriteByte('}')
+	}
+	w.sb.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+func metricsHandler(w http.ResponseWriter, _ *http.Request) {
+	var mw metricWriter
+	writeRoomMetrics(&mw)
+	writeAssistantMetrics(&mw)
+	writeDownloadMetrics(&mw)
+	writeBroadcastMetrics(&mw)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(mw.sb.String()))
+}
+
+func writeRoomMetrics(mw *metricWriter) {
+	type roomInfo struct {
+		chatID string
+		queue  int
+	}
+
+	var active []roomInfo
+	ids := core.GetAllRoomIDs()
+	for _, id := range ids {
+		r, ok := core.GetRoom(id, nil)
+		if !ok || !r.IsActiveChat() {
+			continue
+		}
+		active = append(active, roomInfo{
+			chatID: strconv.FormatInt(id, 10),
+			queue:  len(r.Queue()),
+		})
+	}
+
+	mw.family("yukki_rooms", "gauge", "Rooms held in memory.")
+	mw.sample("yukki_rooms", float64(len(ids)))
+
+	mw.family("yukki_active_rooms", "gauge", "Rooms that are currently playing.")
+	mw.sample("yukki_active_rooms", float64(len(active)))
+
+	mw.family("yukki_queue_length", "gauge", "Tracks waiting in the queue of an active room.")
+	for _, r := range active {
+		mw.sample("yukki_queue_length", float64(r.queue), "chat_id", r.chatID)
+	}
+}
+
+func writeAssistantMetrics(mw *metricWriter) {
+	type assistantInfo struct {
+		index string
+		calls int
+		cpu   float64
+		cpuOK bool
+	}
+
+	var list []assistantInfo
+	core.Assistants.ForEach(func(a *core.Assistant) {
+		info := assistantInfo{
+			index: strconv.Itoa(a.Index),
+			calls: len(a.Ntg.Calls()),
+		}
+		if cpu, err := a.Ntg.CpuUsage(); err == nil {
+			info.cpu, info.cpuOK = cpu, true
+		}
+		list = append(list, info)
+	})
+
+	mw.family("yukki_assistant_calls", "gauge", "Voice chats an assistant is connected to.")
+	for _, a := range list {
+		mw.sample("yukki_assistant_calls", float64(a.calls), "assistant", a.index)
+	}
+
+	mw.family("yukki_ntgcalls_cpu_usage", "gauge", "CPU usage reported by ntgcalls, in percent.")
+	for _, a := range list {
+		if a.cpuOK {
+			mw.sample("yukki_ntgcalls_cpu_usage", a.cpu, "assistant", a.index)
+		}
+	}
+}
+
+func writeDownloadMetrics(mw *metricWriter) {
+	stats := platforms.GetDownloadStats()
+
+	mw.family("yukki_downloads_total", "counter", "Download attempts per platform and result.")
+	for _, s := range stats {
+		p := string(s.Platform)
+		mw.sample("yukki_downloads_total", float64(s.Success), "platform", p, "result", "success")
+		mw.sample("yukki_downloads_total", float64(s.Failure), "platform", p, "result", "failure")
+	}
+
+	mw.family("yukki_download_duration_seconds", "histogram", "Time taken by successful downloads.")
+	for _, s := range stats {
+		p := string(s.Platform)
+		for i, bound := range platforms.DownloadBuckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			mw.sample("yukki_download_duration_seconds_bucket", float64(s.Buckets[i]), "platform", p, "le", le)
+		}
+		mw.sample("yukki_download_duration_seconds_bucket", float64(s.Success), "platform", p, "le", "+Inf")
+		mw.sample("yukki_download_duration_seconds_sum", s.Seconds, "platform", p)
+		mw.sample("yukki_download_duration_seconds_count", float64(s.Success), "platform", p)
+	}
+}
+
+func writeBroadcastMetrics(mw *metricWriter) {
+	if GetBroadcast == nil {
+		return
+	}
+	b := GetBroadcast()
+
+	mw.family("yukki_broadcast_active", "gauge", "Whether a broadcast is running.")
+	mw.sample("yukki_broadcast_active", float64(boolToInt(b.Active)))
+
+	mw.family("yukki_broadcast_targets", "gauge", "Chats and users the last broadcast is sent to.")
+	mw.sample("yukki_broadcast_targets", float64(b.Total))
+
+	mw.family("yukki_broadcast_done", "gauge", "Targets the last broadcast has processed.")
+	mw.sample("yukki_broadcast_done", float64(b.Done))
+
+	mw.family("yukki_broadcast_failed", "gauge", "Targets the last broadcast could not reach.")
+	mw.sample("yukki_broadcast_failed", float64(b.Failed))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+/*
+ * This file is part of YukkiMusic.
+ *
+ * YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+ * Copyright (C) 2025 TheTeamVivek
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Laky-64/gologging"
+
+	"main/internal/config"
+	"main/internal/core"
+)
+
+var mux = http.NewServeMux()
+
+func init() {
+	mux.HandleFunc("GET /healthz", healthzHandler)
+	mux.HandleFunc("GET /metrics", metricsHandler)
+}
+
+// Handle registers h for pattern on the HTTP server.
+func Handle(pattern string, h http.Handler) {
+	mux.Handle(pattern, h)
+}
+
+// HandleFunc registers h for pattern on the HTTP server.
+func HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request)) {
+	mux.HandleFunc(pattern, h)
+}
+
+// Start serves the HTTP endpoints on addr in the background.
+func Start(addr string) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		gologging.InfoF("HTTP server listening on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			gologging.ErrorF("HTTP server stopped: %v", err)
+		}
+	}()
+}
+
+// WriteJSON writes v as the JSON response with the given status code.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		gologging.DebugF("Failed to write response: %v", err)
+	}
+}
+
+func healthzHandler(w http.ResponseWriter, _ *http.Request) {
+	status := http.StatusOK
+	health := "ok"
+	if core.Bot == nil || !core.Bot.IsConnected() {
+		status = http.StatusServiceUnavailable
+		health = "disconnected"
+	}
+
+	WriteJSON(w, status, map[string]any{
+		"status":     health,
+		"uptime":     int(time.Since(config.StartTime).Seconds()),
+		"assistants": core.Assistants.Count(),
+	})
+}
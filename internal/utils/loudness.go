@@ -0,0 +1,85 @@
+/*
+  - This file is part of YukkiMusic.
+    *
+
+  - YukkiMusic — A Telegram bot that streams music into group voice chats with seamless playback and control.
+  - Copyright (C) 2025 TheTeamVivek
+    *
+  - This program is free software: you can redistribute it and/or modify
+  - it under the terms of the GNU General Public License as published by
+  - the Free Software Foundation, either version 3 of the License, or
+  - (at your option) any later version.
+    *
+  - This program is distributed in the hope that it will be useful,
+  - but WITHOUT ANY WARRANTY; without even the implied warranty of
+  - MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+  - GNU General Public License for more details.
+    *
+  - You should have received a copy of the GNU General Public License
+  - along with this program. If not, see <https://www.gnu.org/licenses/>.
+*/
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Loudness is the EBU R128 measurement of a file.
+type Loudness struct {
+	Integrated float64 // LUFS
+	TruePeak   float64 // dBTP
+}
+
+// GetLoudnessByFFmpeg runs a loudnorm analysis pass over the audio of
+// filePath. ffmpeg is killed when ctx is done.
+func GetLoudnessByFFmpeg(ctx context.Context, filePath string) (Loudness, error) {
+	cmd := exec.CommandContext(
+		ctx,
+		"ffmpeg",
+		"-hide_banner", "-nostats",
+		"-i", filePath,
+		"-vn",
+		"-af", "loudnorm=print_format=json",
+		"-f", "null", "-",
+	)
+
+	var out bytes.Buffer
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return Loudness{}, err
+	}
+
+	// the summary is the last JSON object ffmpeg prints
+	result := out.String()
+	start := strings.LastIndex(result, "{")
+	end := strings.LastIndex(result, "}")
+	if start < 0 || end < start {
+		return Loudness{}, errors.New("no loudness summary in ffmpeg output")
+	}
+
+	var summary struct {
+		InputI  string `json:"input_i"`
+		InputTP string `json:"input_tp"`
+	}
+	if err := json.Unmarshal([]byte(result[start:end+1]), &summary); err != nil {
+		return Loudness{}, err
+	}
+
+	integrated, err := strconv.ParseFloat(summary.InputI, 64)
+	if err != nil {
+		return Loudness{}, err
+	}
+	peak, err := strconv.ParseFloat(summary.InputTP, 64)
+	if err != nil {
+		return Loudness{}, err
+	}
+
+	return Loudness{Integrated: integrated, TruePeak: peak}, nil
+}
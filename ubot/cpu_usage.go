@@ -0,0 +1,5 @@
+package ubot
+
+func (ctx *Context) CpuUsage() (float64, error) {
+	return ctx.binding.CpuUsage()
+}